@@ -0,0 +1,375 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	model "github.com/xemkayx/steam-api/pkg/steamclient/model/ISteamWebAPIUtil"
+)
+
+const modulePath = "github.com/xemkayx/steam-api"
+
+// The GetSupportedAPIList response, as the steamclient package decodes it
+type (
+	apiListResponse = model.SupportedAPIListWrapper
+	apiList         = model.SupportedAPIList
+	apiInterface    = model.Interface
+	apiMethod       = model.Method
+	apiParameter    = model.Parameter
+)
+
+func parseAPIList(r io.Reader) (apiList, error) {
+	var resp apiListResponse
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return apiList{}, err
+	}
+	if len(resp.APIList.Interfaces) == 0 {
+		return apiList{}, fmt.Errorf("the API list contains no interfaces")
+	}
+	return resp.APIList, nil
+}
+
+// config of a generator run
+type config struct {
+	Interfaces []string // interfaces to generate, all if empty
+	Existing   names    // identifiers declared by the hand-written files of the package
+}
+
+// file is a generated file
+type file struct {
+	Name    string
+	Content []byte
+}
+
+// data of the templates
+
+type interfaceData struct {
+	Name         string
+	DeclareConst bool
+	Methods      []methodData
+	StdImports   []string
+	Imports      []string // imports of other packages of the module
+}
+
+type methodData struct {
+	Interface     string // name of the interface constant
+	Name          string // name of the Go method
+	TestName      string
+	APIName       string // name of the method in the API
+	EndpointConst string
+	DeclareConst  bool
+	Version       int
+	Post          bool
+	Description   string
+	KeyRequired   bool
+	KeyOptional   bool
+	InputJSON     bool
+	Fields        []fieldData
+	Arguments     []apiParameter
+}
+
+type fieldData struct {
+	Name    string // Go field name
+	Type    string // Go type
+	Comment string
+	Code    string // code that adds the field to vals or inputJson
+	Literal string // entry of the inputJson literal, for required fields of service methods
+}
+
+/*
+generate returns the source files for the interfaces of list.
+
+Methods whose name is already taken by a method of Client in the hand-written code are skipped. Methods of different
+interfaces with the same name are prefixed with their interface name, e.g. StoreServiceGetAppList. The reasons for
+skipped methods are returned as well.
+*/
+func generate(list apiList, cfg config) ([]file, []string, error) {
+	interfaces := append([]apiInterface(nil), list.Interfaces...)
+	sort.Slice(interfaces, func(i, j int) bool { return interfaces[i].Name < interfaces[j].Name })
+
+	taken := map[string]bool{}
+	var files []file
+	var skipped []string
+	for _, iface := range interfaces {
+		if len(cfg.Interfaces) > 0 && !contains(cfg.Interfaces, iface.Name) {
+			continue
+		}
+
+		data := interfaceData{
+			Name:         iface.Name,
+			DeclareConst: !cfg.Existing.Consts[iface.Name],
+		}
+		for _, m := range iface.Methods {
+			if cfg.Existing.Methods[m.Name] {
+				skipped = append(skipped, fmt.Sprintf("%s/%s: Client.%s already exists", iface.Name, m.Name, m.Name))
+				continue
+			}
+			name := m.Name
+			if taken[name] {
+				name = strings.TrimPrefix(iface.Name, "I") + m.Name
+			}
+			if cfg.Existing.Methods[name] || cfg.Existing.Types[name+"Params"] || taken[name] {
+				skipped = append(skipped, fmt.Sprintf("%s/%s: %s is already declared", iface.Name, m.Name, name))
+				continue
+			}
+			taken[name] = true
+			data.Methods = append(data.Methods, newMethodData(iface.Name, name, m, cfg.Existing))
+		}
+		if len(data.Methods) == 0 {
+			continue
+		}
+		data.StdImports, data.Imports = imports(data)
+
+		src, err := execute(sourceTemplate, data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", iface.Name, err)
+		}
+		test, err := execute(testTemplate, data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s test: %w", iface.Name, err)
+		}
+		files = append(files, file{Name: iface.Name + "_gen.go", Content: src}, file{Name: iface.Name + "_gen_test.go", Content: test})
+	}
+	return files, skipped, nil
+}
+
+func newMethodData(iface, name string, m apiMethod, existing names) methodData {
+	md := methodData{
+		Interface:   iface,
+		Name:        name,
+		APIName:     m.Name,
+		Version:     m.Version,
+		Post:        strings.EqualFold(m.HTTPMethod, http.MethodPost),
+		Description: description(name, iface, m),
+		InputJSON:   strings.HasSuffix(iface, "Service"),
+	}
+
+	md.TestName = "Test" + name
+	if existing.Funcs[md.TestName] {
+		md.TestName += "Generated"
+	}
+
+	md.EndpointConst = name + "Endpoint"
+	if value, ok := existing.ConstValues[md.EndpointConst]; ok {
+		if value != m.Name {
+			md.EndpointConst = strings.TrimPrefix(iface, "I") + md.EndpointConst
+		}
+	}
+	md.DeclareConst = !existing.Consts[md.EndpointConst]
+
+	for _, p := range m.Parameters {
+		switch p.Name {
+		case "key":
+			md.KeyRequired = !p.Optional
+			md.KeyOptional = p.Optional
+			continue
+		case "format":
+			continue
+		}
+		md.Arguments = append(md.Arguments, p)
+		md.Fields = append(md.Fields, newFieldData(p, md.InputJSON))
+	}
+	return md
+}
+
+func description(name, iface string, m apiMethod) string {
+	d := fmt.Sprintf("%s calls %s/%s/v%d.", name, iface, m.Name, m.Version)
+	if desc := strings.Join(strings.Fields(m.Description), " "); desc != "" {
+		// a single line without a final period would be formatted as a heading by gofmt
+		if !strings.HasSuffix(desc, ".") && !strings.HasSuffix(desc, "!") && !strings.HasSuffix(desc, "?") {
+			desc += "."
+		}
+		d += "\n\n" + desc
+	}
+	return d
+}
+
+// Go types of the parameter types of the API, with the expressions that format a value of them
+type paramType struct {
+	goType    string
+	queryExpr string // formats %s for a query parameter
+	jsonExpr  string // converts %s for input_json
+	imports   []string
+}
+
+var paramTypes = map[string]paramType{
+	"string":    {goType: "string", queryExpr: "%s", jsonExpr: "%s"},
+	"bool":      {goType: "bool", queryExpr: "strconv.FormatBool(%s)", jsonExpr: "%s", imports: []string{"strconv"}},
+	"int32":     {goType: "int32", queryExpr: "strconv.FormatInt(int64(%s), 10)", jsonExpr: "%s", imports: []string{"strconv"}},
+	"int64":     {goType: "int64", queryExpr: "strconv.FormatInt(%s, 10)", jsonExpr: "%s", imports: []string{"strconv"}},
+	"uint32":    {goType: "uint32", queryExpr: "strconv.FormatUint(uint64(%s), 10)", jsonExpr: "%s", imports: []string{"strconv"}},
+	"uint64":    {goType: "uint64", queryExpr: "strconv.FormatUint(%s, 10)", jsonExpr: "%s", imports: []string{"strconv"}},
+	"fixed32":   {goType: "uint32", queryExpr: "strconv.FormatUint(uint64(%s), 10)", jsonExpr: "%s", imports: []string{"strconv"}},
+	"fixed64":   {goType: "uint64", queryExpr: "strconv.FormatUint(%s, 10)", jsonExpr: "%s", imports: []string{"strconv"}},
+	"float":     {goType: "float32", queryExpr: "strconv.FormatFloat(float64(%s), 'f', -1, 32)", jsonExpr: "%s", imports: []string{"strconv"}},
+	"double":    {goType: "float64", queryExpr: "strconv.FormatFloat(%s, 'f', -1, 64)", jsonExpr: "%s", imports: []string{"strconv"}},
+	"{enum}":    {goType: "int32", queryExpr: "strconv.FormatInt(int64(%s), 10)", jsonExpr: "%s", imports: []string{"strconv"}},
+	"{message}": {goType: "json.RawMessage", queryExpr: "string(%s)", jsonExpr: "%s", imports: []string{"encoding/json"}},
+	"steamid":   {goType: "steamid.ID", queryExpr: "%s.String()", jsonExpr: "%s.Uint64()", imports: []string{modulePath + "/pkg/steamid"}},
+}
+
+func typeOf(p apiParameter) paramType {
+	lower := strings.ToLower(p.Name)
+	if strings.Contains(lower, "steamid") && (p.Type == "uint64" || p.Type == "fixed64") {
+		return paramTypes["steamid"]
+	}
+	if t, ok := paramTypes[p.Type]; ok {
+		return t
+	}
+	return paramTypes["string"]
+}
+
+func newFieldData(p apiParameter, inputJSON bool) fieldData {
+	apiName, isList := strings.CutSuffix(p.Name, "[0]")
+	t := typeOf(p)
+	f := fieldData{Name: goName(apiName), Comment: strings.Join(strings.Fields(p.Description), " ")}
+	if p.Optional && !isList {
+		f.Comment = strings.TrimSpace("(optional) " + f.Comment)
+	}
+	field := "params." + f.Name
+
+	switch {
+	case apiName == "steamids" && p.Type == "string":
+		// comma-separated list of SteamIDs, like GetPlayerSummaries takes them
+		f.Type = "[]steamid.ID"
+		f.Code = fmt.Sprintf(`strSlice := make([]string, len(%[1]s))
+for i, id := range %[1]s {
+	strSlice[i] = id.String()
+}
+vals.Set(%[2]q, strings.Join(strSlice, ","))`, field, apiName)
+		if inputJSON {
+			f.Code = fmt.Sprintf("inputJson[%q] = %s", apiName, field)
+		}
+
+	case isList:
+		f.Type = "[]" + t.goType
+		if inputJSON {
+			f.Code = fmt.Sprintf("if len(%[1]s) > 0 {\ninputJson[%[2]q] = %[1]s\n}", field, apiName)
+		} else {
+			f.Code = fmt.Sprintf("for i, v := range %s {\nvals.Set(fmt.Sprintf(\"%s[%%d]\", i), %s)\n}", field, apiName, fmt.Sprintf(t.queryExpr, "v"))
+		}
+
+	case p.Optional:
+		f.Type = "*" + t.goType
+		if inputJSON {
+			f.Code = fmt.Sprintf("if %[1]s != nil {\ninputJson[%[2]q] = %[3]s\n}", field, apiName, fmt.Sprintf(t.jsonExpr, "*"+field))
+		} else {
+			f.Code = fmt.Sprintf("if %[1]s != nil {\nvals.Set(%[2]q, %[3]s)\n}", field, apiName, fmt.Sprintf(t.queryExpr, "*"+field))
+		}
+
+	default:
+		f.Type = t.goType
+		if inputJSON {
+			f.Literal = fmt.Sprintf("%q: %s,", apiName, fmt.Sprintf(t.jsonExpr, field))
+		} else {
+			f.Code = fmt.Sprintf("vals.Set(%q, %s)", apiName, fmt.Sprintf(t.queryExpr, field))
+		}
+	}
+	return f
+}
+
+// words that are written differently than just capitalized, to match the hand-written code
+var knownWords = map[string]string{
+	"appid":     "AppId",
+	"appids":    "AppIds",
+	"gameid":    "GameId",
+	"steamid":   "SteamId",
+	"steamids":  "SteamIds",
+	"url":       "URL",
+	"vanityurl": "VanityURL",
+	"startdate": "StartDate",
+	"enddate":   "EndDate",
+}
+
+// goName converts a parameter name like include_appinfo into a field name like IncludeAppinfo
+func goName(s string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		if w, ok := knownWords[strings.ToLower(part)]; ok {
+			b.WriteString(w)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	name := b.String()
+	if name == "" || name[0] < 'A' || name[0] > 'Z' {
+		name = "P" + name
+	}
+	return name
+}
+
+// imports returns the standard library and module imports the generated source of data needs
+func imports(data interfaceData) (std, module []string) {
+	set := map[string]bool{
+		"context":                              true,
+		"net/url":                              true,
+		modulePath + "/pkg/steamclient/config": true,
+	}
+	for _, m := range data.Methods {
+		if m.InputJSON {
+			set["encoding/json"] = true
+			set["fmt"] = true
+		}
+		for _, p := range m.Arguments {
+			apiName, isList := strings.CutSuffix(p.Name, "[0]")
+			if apiName == "steamids" && p.Type == "string" {
+				set[modulePath+"/pkg/steamid"] = true
+				if !m.InputJSON {
+					set["strings"] = true
+				}
+				continue
+			}
+			if isList && !m.InputJSON {
+				set["fmt"] = true
+			}
+			for _, imp := range typeOf(p).imports {
+				// input_json doesn't need to format values
+				if imp == "strconv" && m.InputJSON {
+					continue
+				}
+				set[imp] = true
+			}
+		}
+	}
+
+	for imp := range set {
+		if strings.HasPrefix(imp, modulePath+"/") {
+			module = append(module, strconv.Quote(imp))
+		} else {
+			std = append(std, strconv.Quote(imp))
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(module)
+	module = append([]string{`urlHelper "` + modulePath + `/internal/urlHelper"`}, module...)
+	return std, module
+}
+
+func execute(tmpl *template.Template, data interfaceData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func loadAPIList(t *testing.T) apiList {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", "supported_api_list.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	list, err := parseAPIList(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return list
+}
+
+func TestGenerate(t *testing.T) {
+	existing, err := declaredNames(filepath.Join("testdata", "existing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, _, err := generate(loadAPIList(t), config{Existing: existing})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name)
+
+		golden := filepath.Join("testdata", "golden", f.Name+".golden")
+		if *update {
+			if err := os.WriteFile(golden, f.Content, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, string(want), string(f.Content), "%s differs from %s, run go test -update to update it", f.Name, golden)
+	}
+
+	// GetFriendList is only declared in a generated file, which is regenerated
+	assert.Equal(t, []string{
+		"IPlayerService_gen.go", "IPlayerService_gen_test.go",
+		"ISteamApps_gen.go", "ISteamApps_gen_test.go",
+		"ISteamUser_gen.go", "ISteamUser_gen_test.go",
+		"ISteamUserStats_gen.go", "ISteamUserStats_gen_test.go",
+		"ISteamWebAPIUtil_gen.go", "ISteamWebAPIUtil_gen_test.go",
+	}, names)
+}
+
+func TestGenerateExisting(t *testing.T) {
+	existing, err := declaredNames(filepath.Join("testdata", "existing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, skipped, err := generate(loadAPIList(t), config{Existing: existing, Interfaces: []string{"IPlayerService", "ISteamApps"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, files, 4)
+	assert.Contains(t, skipped, "IPlayerService/GetOwnedGames: Client.GetOwnedGames already exists")
+
+	player := string(files[0].Content)
+	assert.NotContains(t, player, "func (c Client) GetOwnedGames(", "existing methods are skipped")
+	assert.NotContains(t, player, "IPlayerService = ", "existing constants are not declared again")
+	assert.Contains(t, player, "GetSteamLevelEndpoint       = \"GetSteamLevel\"")
+	assert.Contains(t, player, "func (c Client) GetSteamLevel(params GetSteamLevelParams, out any) error")
+	assert.Contains(t, string(files[1].Content), "func TestGetSteamLevelGenerated(", "test names don't collide")
+	assert.Contains(t, player, "\nReturns the Steam Level of a user.\n", "one-line descriptions must not become headings")
+	assert.NotContains(t, player, "# Returns")
+
+	apps := string(files[2].Content)
+	assert.NotContains(t, apps, "GetAppListEndpoint =")
+	assert.Contains(t, apps, "EndpointPath: GetAppListEndpoint")
+}
+
+func TestGenerateDuplicateMethods(t *testing.T) {
+	list := apiList{Interfaces: []apiInterface{
+		{Name: "IStoreService", Methods: []apiMethod{{Name: "GetAppList", Version: 1, HTTPMethod: "GET"}}},
+		{Name: "ISteamApps", Methods: []apiMethod{{Name: "GetAppList", Version: 2, HTTPMethod: "GET"}}},
+	}}
+
+	files, _, err := generate(list, config{Existing: newNames()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, files, 4)
+	assert.Contains(t, string(files[0].Content), "func (c Client) GetAppList(params GetAppListParams, out any) error")
+	assert.Contains(t, string(files[2].Content), "func (c Client) StoreServiceGetAppList(params StoreServiceGetAppListParams, out any) error")
+	assert.Contains(t, string(files[2].Content), "StoreServiceGetAppListEndpoint = \"GetAppList\"")
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"steamid":                   "SteamId",
+		"include_played_free_games": "IncludePlayedFreeGames",
+		"appids_filter":             "AppIdsFilter",
+		"vanityurl":                 "VanityURL",
+		"max_results":               "MaxResults",
+		"if_modified_since":         "IfModifiedSince",
+		"l":                         "L",
+		"2fa":                       "P2fa",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, goName(in), in)
+	}
+}
+
+func TestDeclaredNames(t *testing.T) {
+	n, err := declaredNames(filepath.Join("testdata", "existing"))
+	assert.NoError(t, err)
+	assert.True(t, n.Consts["IPlayerService"])
+	assert.Equal(t, "GetOwnedGames", n.ConstValues["GetOwnedGamesEndpoint"])
+	assert.True(t, n.Types["Client"])
+	assert.True(t, n.Methods["GetOwnedGames"])
+	assert.True(t, n.Methods["ResolveVanityURL"], "pointer receivers count as well")
+	assert.True(t, n.Funcs["TestGetSteamLevel"])
+	assert.False(t, n.Methods["GetFriendList"], "generated files are ignored")
+
+	n, err = declaredNames(filepath.Join("testdata", "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, n.Methods)
+}
+
+func TestParseAPIList(t *testing.T) {
+	_, err := parseAPIList(strings.NewReader(`{"apilist":{"interfaces":[]}}`))
+	assert.Error(t, err)
+	_, err = parseAPIList(strings.NewReader(`not json`))
+	assert.Error(t, err)
+}
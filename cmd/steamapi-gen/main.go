@@ -0,0 +1,93 @@
+/*
+Steamapi-gen generates typed endpoints for the steamclient package from a saved
+ISteamWebAPIUtil/GetSupportedAPIList response.
+
+For every interface it writes <Interface>_gen.go, holding the interface and endpoint constants, a params struct
+and a method pair (X and XCtx) per method, and <Interface>_gen_test.go with an httpmock test skeleton per method.
+Test skeletons are meant to be completed by hand, so existing ones are not overwritten. Methods, constants and
+types that are already declared in the hand-written files of the package are skipped, so the generated code can
+live next to them.
+
+Usage:
+
+	steamapi-gen -in supported_api_list.json [-out pkg/steamclient] [-interfaces ISteamApps,IStoreService]
+
+The input can be recorded with:
+
+	curl "https://api.steampowered.com/ISteamWebAPIUtil/GetSupportedAPIList/v1?key=<key>" > supported_api_list.json
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	in := flag.String("in", "", "saved GetSupportedAPIList JSON response (required)")
+	out := flag.String("out", "pkg/steamclient", "directory the files are written to")
+	interfaces := flag.String("interfaces", "", "comma-separated list of interfaces to generate (default: all)")
+	flag.Parse()
+
+	if *in == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var cfg config
+	if *interfaces != "" {
+		cfg.Interfaces = strings.Split(*interfaces, ",")
+	}
+	if err := run(*in, *out, cfg); err != nil {
+		fmt.Fprintln(os.Stderr, "steamapi-gen:", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the files for the API list in inPath into outDir
+func run(inPath, outDir string, cfg config) error {
+	f, err := os.Open(inPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	list, err := parseAPIList(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", inPath, err)
+	}
+
+	cfg.Existing, err = declaredNames(outDir)
+	if err != nil {
+		return err
+	}
+
+	files, skipped, err := generate(list, cfg)
+	if err != nil {
+		return err
+	}
+	for _, reason := range skipped {
+		fmt.Fprintln(os.Stderr, "skipping", reason)
+	}
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+	for _, file := range files {
+		path := filepath.Join(outDir, file.Name)
+		if strings.HasSuffix(file.Name, "_test.go") {
+			if _, err := os.Stat(path); err == nil {
+				fmt.Println("keeping", file.Name)
+				continue
+			}
+		}
+		if err := os.WriteFile(path, file.Content, 0o644); err != nil {
+			return err
+		}
+		fmt.Println("wrote", file.Name)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// names declared in the hand-written files of a package
+type names struct {
+	Consts      map[string]bool
+	ConstValues map[string]string // values of the string constants
+	Types       map[string]bool
+	Funcs       map[string]bool // functions without receiver, including tests
+	Methods     map[string]bool // methods of Client
+}
+
+func newNames() names {
+	return names{
+		Consts:      map[string]bool{},
+		ConstValues: map[string]string{},
+		Types:       map[string]bool{},
+		Funcs:       map[string]bool{},
+		Methods:     map[string]bool{},
+	}
+}
+
+// isGenerated reports if name is a file written by the generator
+func isGenerated(name string) bool {
+	return strings.HasSuffix(name, "_gen.go") || strings.HasSuffix(name, "_gen_test.go")
+}
+
+// declaredNames collects the names declared in the Go files in dir, apart from the generated ones.
+// A missing dir declares nothing.
+func declaredNames(dir string) (names, error) {
+	n := newNames()
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return n, nil
+	}
+	if err != nil {
+		return n, err
+	}
+
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || isGenerated(entry.Name()) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return n, err
+		}
+		for _, decl := range f.Decls {
+			n.add(decl)
+		}
+	}
+	return n, nil
+}
+
+func (n names) add(decl ast.Decl) {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Recv == nil {
+			n.Funcs[d.Name.Name] = true
+			return
+		}
+		if len(d.Recv.List) == 1 && receiverName(d.Recv.List[0].Type) == "Client" {
+			n.Methods[d.Name.Name] = true
+		}
+
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				n.Types[s.Name.Name] = true
+			case *ast.ValueSpec:
+				if d.Tok != token.CONST {
+					continue
+				}
+				for i, ident := range s.Names {
+					n.Consts[ident.Name] = true
+					if i >= len(s.Values) {
+						continue
+					}
+					if lit, ok := s.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						if value, err := strconv.Unquote(lit.Value); err == nil {
+							n.ConstValues[ident.Name] = value
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
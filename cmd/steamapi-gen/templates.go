@@ -0,0 +1,165 @@
+package main
+
+import (
+	"strings"
+	"text/template"
+)
+
+var funcs = template.FuncMap{
+	// clean makes a description fit into a comment
+	"clean": func(s string) string {
+		return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "*/", "* /")
+	},
+	"split": func(s string) []string { return strings.Split(s, "\n") },
+	"hasConsts": func(data interfaceData) bool {
+		if data.DeclareConst {
+			return true
+		}
+		for _, m := range data.Methods {
+			if m.DeclareConst {
+				return true
+			}
+		}
+		return false
+	},
+}
+
+var sourceTemplate = template.Must(template.New("source").Funcs(funcs).Parse(`// Code generated by steamapi-gen from GetSupportedAPIList. DO NOT EDIT.
+
+package steamclient
+
+import (
+{{- range .StdImports}}
+	{{.}}
+{{- end}}
+
+{{range .Imports}}
+	{{.}}
+{{- end}}
+)
+{{if hasConsts .}}
+const (
+{{- if .DeclareConst}}
+	{{.Name}} = "{{.Name}}"
+{{- end}}
+{{- range .Methods}}{{if .DeclareConst}}
+	{{.EndpointConst}} = "{{.APIName}}" // v{{printf "%04d" .Version}}
+{{- end}}{{end}}
+)
+{{end}}
+{{- range .Methods}}
+// Parameters for the {{.Name}} method
+type {{.Name}}Params struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}}{{with clean .Comment}} // {{.}}{{end}}
+{{- end}}
+	Format config.OutputFormat // Format of the output
+}
+{{end}}
+{{- range .Methods}}
+/*
+{{range split .Description}}{{clean .}}
+{{end}}
+{{- if .KeyRequired}}
+
+# Key required
+{{- end}}
+
+Arguments
+{{- range .Arguments}}
+  - {{.Name}}{{with clean .Description}}
+    {{.}}{{end}}
+{{- end}}
+  - format
+    Output format. json (default), xml or vdf.
+
+The answer is decoded into out, without the "response" object it's wrapped in (see Client.Call).
+*/
+func (c Client) {{.Name}}(params {{.Name}}Params, out any) error {
+	return c.{{.Name}}Ctx(context.Background(), params, out)
+}
+
+// {{.Name}}Ctx is like {{.Name}} but sends the request with the given context.
+func (c Client) {{.Name}}Ctx(ctx context.Context, params {{.Name}}Params, out any) error {
+{{- if .KeyRequired}}
+	if !c.IsKeySet() {
+		return ErrKeyRequired
+	}
+{{- end}}
+	version := "{{.Version}}"
+{{if .InputJSON}}
+	inputJson := map[string]interface{}{
+{{- range .Fields}}{{with .Literal}}
+		{{.}}
+{{- end}}{{end}}
+	}
+{{range .Fields}}{{with .Code}}
+	{{.}}
+{{end}}{{end}}
+	jsonBytes, err := json.Marshal(inputJson)
+	if err != nil {
+		return fmt.Errorf("error marshaling JSON: %s", err)
+	}
+{{end}}
+	vals := url.Values{}
+{{- if .KeyRequired}}
+	vals.Set("key", c.Key)
+{{- else if .KeyOptional}}
+	if c.IsKeySet() {
+		vals.Set("key", c.Key)
+	}
+{{- end}}
+	vals.Set("format", params.Format.String())
+{{- if .InputJSON}}
+	vals.Set("input_json", string(jsonBytes))
+{{- else}}
+{{range .Fields}}
+	{{.Code}}
+{{- end}}
+{{- end}}
+
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: {{.EndpointConst}}, Version: version}
+	resp, err := c.{{if .Post}}post{{else}}get{{end}}(ctx, {{.Interface}}, versUrlEndpoint, vals)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	return decodeUnwrapped(params.Format, resp.Body, out)
+}
+{{end}}`))
+
+var testTemplate = template.Must(template.New("test").Funcs(funcs).Parse(`// Test skeletons generated by steamapi-gen from GetSupportedAPIList.
+// Replace the answers with recorded ones and check the decoded values.
+
+package steamclient
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+{{range .Methods}}
+func {{.TestName}}(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("{{if .Post}}POST{{else}}GET{{end}}", "https://api.steampowered.com/{{.Interface}}/{{.APIName}}/v{{.Version}}",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "json", req.FormValue("format"))
+			return httpmock.NewStringResponse(200, ` + "`" + `{"response":{}}` + "`" + `), nil
+		})
+
+	client := New("test-key", &http.Client{})
+
+	var out map[string]interface{}
+	err := client.{{.Name}}({{.Name}}Params{Format: config.Json}, &out)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+{{end}}`))
@@ -0,0 +1,14 @@
+// Package steamclient stands in for the hand-written files of the generated package
+package steamclient
+
+const (
+	IPlayerService        = "IPlayerService"
+	GetOwnedGamesEndpoint = "GetOwnedGames" // v0001
+	GetAppListEndpoint    = "GetAppList"    // v0002
+)
+
+type Client struct{}
+
+func (c Client) GetOwnedGames() {}
+
+func (c *Client) ResolveVanityURL() {}
@@ -0,0 +1,5 @@
+package steamclient
+
+import "testing"
+
+func TestGetSteamLevel(t *testing.T) {}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// scalar describes how a value of a scalar type is read from a protowire.Reader
+type scalar struct {
+	GoType string // Go type the value is read as
+	Read   string // expression reading the value from r
+	Varint bool   // encoded as a varint, so it can be packed
+}
+
+var scalars = map[string]scalar{
+	"int32":   {GoType: "int32", Read: "int32(r.Varint())", Varint: true},
+	"int64":   {GoType: "int64", Read: "int64(r.Varint())", Varint: true},
+	"uint32":  {GoType: "uint32", Read: "uint32(r.Varint())", Varint: true},
+	"uint64":  {GoType: "uint64", Read: "r.Varint()", Varint: true},
+	"bool":    {GoType: "bool", Read: "r.Bool()"},
+	"string":  {GoType: "string", Read: "r.String()"},
+	"fixed32": {GoType: "uint32", Read: "r.Fixed32()"},
+	"fixed64": {GoType: "uint64", Read: "r.Fixed64()"},
+}
+
+// generate returns the formatted Go source of the messages of file that have a gotype
+func generate(file *protoFile, pkg, source string) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by steamapi-protogen from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintf(&buf, "import %q\n", "github.com/xemkayx/steam-api/internal/protowire")
+
+	for _, m := range file.Messages {
+		if m.GoType == "" {
+			continue
+		}
+		var err error
+		if strings.HasSuffix(m.Name, "_Request") {
+			err = writeRequest(&buf, m)
+		} else {
+			err = writeResponse(&buf, m)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("message %s: %w", m.Name, err)
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %w", err)
+	}
+	return src, nil
+}
+
+// writeRequest writes the struct of a request message and its MarshalProto method
+func writeRequest(buf *bytes.Buffer, m *message) error {
+	var fields, marshal strings.Builder
+	for _, f := range m.Fields {
+		if f.GoName == "" {
+			continue
+		}
+		s, ok := scalars[f.Type]
+		if !ok || (f.Repeated && !s.Varint) || strings.HasPrefix(f.Type, "fixed") {
+			return fmt.Errorf("line %d: %s fields are not supported in requests", f.Line, label(f))
+		}
+		goType := s.GoType
+		if f.Repeated {
+			goType = "[]" + goType
+		}
+		if f.GoType != goType {
+			return fmt.Errorf("line %d: field %s of type %s needs the Go type %s", f.Line, f.Name, label(f), goType)
+		}
+		fmt.Fprintf(&fields, "\t%s %s // %d\n", f.GoName, goType, f.Number)
+
+		value := "m." + f.GoName
+		switch {
+		case f.Repeated:
+			fmt.Fprintf(&marshal, "\tfor _, v := range %s {\n", value)
+			fmt.Fprintf(&marshal, "\t\tb = protowire.AppendVarint(protowire.AppendTag(b, %d, protowire.Varint), %s)\n", f.Number, convert("uint64", s.GoType, "v"))
+			fmt.Fprintf(&marshal, "\t}\n")
+		case f.Type == "bool":
+			fmt.Fprintf(&marshal, "\tb = protowire.AppendBoolField(b, %d, %s)\n", f.Number, value)
+		case f.Type == "string":
+			fmt.Fprintf(&marshal, "\tb = protowire.AppendStringField(b, %d, %s)\n", f.Number, value)
+		default:
+			fmt.Fprintf(&marshal, "\tb = protowire.AppendVarintField(b, %d, %s)\n", f.Number, convert("uint64", s.GoType, value))
+		}
+	}
+
+	fmt.Fprintf(buf, "\n// %s is %s\n", m.GoType, m.Name)
+	fmt.Fprintf(buf, "type %s struct {\n%s}\n", m.GoType, fields.String())
+	fmt.Fprintf(buf, "\n// MarshalProto encodes the message\n")
+	fmt.Fprintf(buf, "func (m %s) MarshalProto() []byte {\n\tvar b []byte\n%s\treturn b\n}\n", m.GoType, marshal.String())
+	return nil
+}
+
+// writeResponse writes the UnmarshalProto method of a message into its Go type
+func writeResponse(buf *bytes.Buffer, m *message) error {
+	var cases strings.Builder
+	for _, f := range m.Fields {
+		if f.GoName == "" {
+			continue
+		}
+		fmt.Fprintf(&cases, "\t\tcase %d:\n", f.Number)
+		value := "m." + f.GoName
+
+		if f.Message != nil {
+			if f.Message.GoType == "" {
+				return fmt.Errorf("line %d: message %s of field %s has no gotype", f.Line, f.Message.Name, f.Name)
+			}
+			if !f.Repeated {
+				fmt.Fprintf(&cases, "\t\t\tif err := %s.UnmarshalProto(r.Bytes()); err != nil {\n\t\t\t\treturn err\n\t\t\t}\n", value)
+				continue
+			}
+			fmt.Fprintf(&cases, "\t\t\tvar v %s\n", f.Message.GoType)
+			fmt.Fprintf(&cases, "\t\t\tif err := v.UnmarshalProto(r.Bytes()); err != nil {\n\t\t\t\treturn err\n\t\t\t}\n")
+			fmt.Fprintf(&cases, "\t\t\t%s = append(%s, v)\n", value, value)
+			continue
+		}
+
+		s := scalars[f.Type]
+		goType, repeated := strings.CutPrefix(f.GoType, "[]")
+		if goType == "" || repeated != f.Repeated {
+			return fmt.Errorf("line %d: field %s of type %s needs a Go type like %s", f.Line, f.Name, label(f), example(f, s))
+		}
+		switch {
+		case !f.Repeated:
+			fmt.Fprintf(&cases, "\t\t\t%s = %s\n", value, convert(goType, s.GoType, s.Read))
+		case s.Varint:
+			// packed or one value per field
+			fmt.Fprintf(&cases, "\t\t\tfor _, v := range r.Varints(nil) {\n")
+			fmt.Fprintf(&cases, "\t\t\t\t%s = append(%s, %s)\n", value, value, convert(goType, s.GoType, convert(s.GoType, "uint64", "v")))
+			fmt.Fprintf(&cases, "\t\t\t}\n")
+		case f.Type == "string":
+			fmt.Fprintf(&cases, "\t\t\t%s = append(%s, %s)\n", value, value, convert(goType, s.GoType, s.Read))
+		default:
+			return fmt.Errorf("line %d: %s fields are not supported", f.Line, label(f))
+		}
+	}
+
+	fmt.Fprintf(buf, "\n// UnmarshalProto decodes %s\n", m.Name)
+	fmt.Fprintf(buf, "func (m *%s) UnmarshalProto(b []byte) error {\n", m.GoType)
+	fmt.Fprintf(buf, "\tr := protowire.NewReader(b)\n\tfor r.Next() {\n")
+	if cases.Len() > 0 {
+		fmt.Fprintf(buf, "\t\tswitch r.Num() {\n%s\t\t}\n", cases.String())
+	}
+	fmt.Fprintf(buf, "\t}\n\treturn r.Err()\n}\n")
+	return nil
+}
+
+// convert returns the Go expression converting expr of the type from to the type to
+func convert(to, from, expr string) string {
+	if to == from {
+		return expr
+	}
+	return to + "(" + expr + ")"
+}
+
+// label returns the type of f as declared, e.g. "repeated uint32"
+func label(f field) string {
+	if f.Repeated {
+		return "repeated " + f.Type
+	}
+	return f.Type
+}
+
+// example returns the Go type a field reads as without conversion
+func example(f field, s scalar) string {
+	if f.Repeated {
+		return "[]" + s.GoType
+	}
+	return s.GoType
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the messages of the steamclient models, generated by go generate
+var modelDir = filepath.Join("..", "..", "pkg", "steamclient", "model", "IPlayerService")
+
+func TestGenerateIsUpToDate(t *testing.T) {
+	f, err := os.Open(filepath.Join(modelDir, "player.proto"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	file, err := parseProto(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate(file, "model", "player.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := os.ReadFile(filepath.Join(modelDir, "player.pb.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(want), string(got), "player.pb.go is outdated, run go generate in %s", modelDir)
+}
+
+func TestParseProto(t *testing.T) {
+	file, err := parseProto(strings.NewReader(`syntax = "proto2";
+
+// gotype: Outer
+message Outer {
+	message Inner {
+		optional int32 id = 1;
+	}
+	optional Inner inner = 1; // gofield: Inner
+	repeated .Outer.Inner inners = 2 [packed = true]; // gofield: Inners
+	reserved 3;
+}
+`))
+	assert.NoError(t, err)
+	if assert.Len(t, file.Messages, 2) {
+		outer, inner := file.Messages[0], file.Messages[1]
+		assert.Equal(t, "Outer", outer.Name)
+		assert.Equal(t, "Outer", outer.GoType)
+		assert.Equal(t, "Outer.Inner", inner.Name)
+		assert.Equal(t, "", inner.GoType)
+		assert.Equal(t, []field{
+			{Line: 8, Type: "Outer.Inner", Message: inner, Name: "inner", Number: 1, GoName: "Inner"},
+			{Line: 9, Repeated: true, Type: "Outer.Inner", Message: inner, Name: "inners", Number: 2, GoName: "Inners"},
+		}, outer.Fields)
+	}
+}
+
+func TestParseProtoErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		proto string
+		want  string
+	}{
+		{name: "field outside of a message", proto: "optional int32 id = 1;", want: "line 1: field outside of a message"},
+		{name: "unclosed message", proto: "message A {\n", want: "message A is not closed"},
+		{name: "unexpected brace", proto: "}", want: "line 1: unexpected }"},
+		{name: "map field", proto: "message A {\n\tmap<string, int32> m = 1;\n}", want: "line 2: unsupported declaration"},
+		{name: "unknown type", proto: "message A {\n\toptional B b = 1;\n}", want: "line 2: unknown type B"},
+		{name: "invalid gofield", proto: "message A {\n\toptional int32 id = 1; // gofield: a b c\n}", want: "line 2: expected \"gofield"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseProto(strings.NewReader(tt.proto))
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		proto string
+		want  string
+	}{
+		{
+			name:  "request with a converted type",
+			proto: "// gotype: Req\nmessage A_Request {\n\toptional int32 id = 1; // gofield: Id int\n}",
+			want:  "line 3: field id of type int32 needs the Go type int32",
+		},
+		{
+			name:  "message field in a request",
+			proto: "message B {\n}\n// gotype: Req\nmessage A_Request {\n\toptional B b = 1; // gofield: B\n}",
+			want:  "line 5: B fields are not supported in requests",
+		},
+		{
+			name:  "response without a Go type",
+			proto: "// gotype: Resp\nmessage A {\n\toptional int32 id = 1; // gofield: Id\n}",
+			want:  "line 3: field id of type int32 needs a Go type like int32",
+		},
+		{
+			name:  "message without gotype",
+			proto: "message B {\n}\n// gotype: Resp\nmessage A {\n\trepeated B b = 1; // gofield: B\n}",
+			want:  "line 5: message B of field b has no gotype",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := parseProto(strings.NewReader(tt.proto))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = generate(file, "model", "test.proto")
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
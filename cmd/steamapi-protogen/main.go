@@ -0,0 +1,73 @@
+/*
+Steamapi-protogen generates the protobuf messages of the steamclient models from a .proto file. The generated code
+encodes and decodes the wire format with internal/protowire, so the models need no protobuf runtime.
+
+It understands the subset of proto2 the message definitions of the Steam client use: messages, nested messages,
+and scalar, string and message fields, one declaration per line. Comments map the messages to Go types:
+
+	// gotype: OwnedGamesRequest
+	message CPlayer_GetOwnedGames_Request {
+		optional uint64 steamid = 1; // gofield: SteamId uint64
+		optional bool include_free_sub = 5;
+	}
+
+Messages ending in _Request become a struct with a MarshalProto method. Every other message with a gotype gets
+an UnmarshalProto method on that type, which is usually the JSON model of the answer and declared by hand. Fields
+without a gofield comment are left out of requests and skipped when decoding. Message fields only need the name of
+the Go field.
+
+Usage:
+
+	steamapi-protogen -in player.proto -out player.pb.go [-package model]
+
+It's meant to be run by go generate, which provides the package name.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	in := flag.String("in", "", ".proto file to generate the messages of (required)")
+	out := flag.String("out", "", "Go file the messages are written to (required)")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated file")
+	flag.Parse()
+
+	if *in == "" || *out == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*in, *out, *pkg); err != nil {
+		fmt.Fprintln(os.Stderr, "steamapi-protogen:", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the messages of the .proto file inPath into outPath
+func run(inPath, outPath, pkg string) error {
+	f, err := os.Open(inPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	file, err := parseProto(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", inPath, err)
+	}
+
+	content, err := generate(file, pkg, filepath.Base(inPath))
+	if err != nil {
+		return fmt.Errorf("%s: %w", inPath, err)
+	}
+	if err := os.WriteFile(outPath, content, 0o644); err != nil {
+		return err
+	}
+	fmt.Println("wrote", filepath.Base(outPath))
+	return nil
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// protoFile holds the messages of a .proto file in the order they are declared, nested messages after their parent
+type protoFile struct {
+	Messages []*message
+}
+
+type message struct {
+	Name   string // full name, e.g. CPlayer_GetOwnedGames_Response.Game
+	GoType string // from the gotype comment, empty if the message is only used as a field type
+	Fields []field
+}
+
+type field struct {
+	Line     int
+	Repeated bool
+	Type     string   // scalar type or full name of the message
+	Message  *message // the message of the type, nil for scalars
+	Name     string
+	Number   int
+	GoName   string // from the gofield comment, empty if the field is left out
+	GoType   string
+}
+
+var (
+	fieldPattern = regexp.MustCompile(`^(optional|required|repeated)\s+(\.?[A-Za-z_][\w.]*)\s+([A-Za-z_]\w*)\s*=\s*(\d+)\s*(\[[^\]]*\])?\s*;$`)
+	identPattern = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+)
+
+// parseProto reads the messages of a .proto file and resolves the types of their fields
+func parseProto(r io.Reader) (*protoFile, error) {
+	var (
+		file   protoFile
+		stack  []*message // the messages the current line is in
+		goType string     // of the next message
+	)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		decl, comment := splitComment(scanner.Text())
+		keyword, _, _ := strings.Cut(decl, " ")
+
+		switch {
+		case decl == "":
+			if v, ok := directive(comment, "gotype:"); ok {
+				goType = v
+			}
+
+		case keyword == "message":
+			name, ok := strings.CutSuffix(strings.TrimSpace(strings.TrimPrefix(decl, "message")), "{")
+			name = strings.TrimSpace(name)
+			if !ok || !identPattern.MatchString(name) {
+				return nil, fmt.Errorf("line %d: expected \"message <name> {\"", line)
+			}
+			if len(stack) > 0 {
+				name = stack[len(stack)-1].Name + "." + name
+			}
+			m := &message{Name: name, GoType: goType}
+			goType = ""
+			file.Messages = append(file.Messages, m)
+			stack = append(stack, m)
+
+		case decl == "}":
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: unexpected }", line)
+			}
+			stack = stack[:len(stack)-1]
+
+		case keyword == "syntax", keyword == "package", keyword == "import", keyword == "option", keyword == "reserved":
+			// nothing to generate
+
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: field outside of a message", line)
+			}
+			f, err := parseField(decl, comment)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			f.Line = line
+			m := stack[len(stack)-1]
+			m.Fields = append(m.Fields, f)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("message %s is not closed", stack[len(stack)-1].Name)
+	}
+
+	if err := file.resolve(); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// parseField parses the declaration of a field and its gofield comment
+func parseField(decl, comment string) (field, error) {
+	match := fieldPattern.FindStringSubmatch(decl)
+	if match == nil {
+		return field{}, fmt.Errorf("unsupported declaration %q", decl)
+	}
+	number, err := strconv.Atoi(match[4])
+	if err != nil || number <= 0 {
+		return field{}, fmt.Errorf("invalid field number %s", match[4])
+	}
+	f := field{
+		Repeated: match[1] == "repeated",
+		Type:     match[2],
+		Name:     match[3],
+		Number:   number,
+	}
+
+	if v, ok := directive(comment, "gofield:"); ok {
+		parts := strings.Fields(v)
+		if len(parts) == 0 || len(parts) > 2 || !identPattern.MatchString(parts[0]) {
+			return field{}, fmt.Errorf("expected \"gofield: <name> [<type>]\", got %q", comment)
+		}
+		f.GoName = parts[0]
+		if len(parts) == 2 {
+			f.GoType = parts[1]
+		}
+	}
+	return f, nil
+}
+
+// resolve looks up the messages of the field types, relative to the message of the field like protoc does
+func (file *protoFile) resolve() error {
+	byName := map[string]*message{}
+	for _, m := range file.Messages {
+		byName[m.Name] = m
+	}
+
+	for _, m := range file.Messages {
+		for i := range m.Fields {
+			f := &m.Fields[i]
+			if _, ok := scalars[f.Type]; ok {
+				continue
+			}
+			f.Message = lookup(byName, m.Name, f.Type)
+			if f.Message == nil {
+				return fmt.Errorf("line %d: unknown type %s", f.Line, f.Type)
+			}
+			f.Type = f.Message.Name
+		}
+	}
+	return nil
+}
+
+func lookup(byName map[string]*message, scope, name string) *message {
+	if full, ok := strings.CutPrefix(name, "."); ok {
+		return byName[full]
+	}
+	for {
+		if m, ok := byName[scope+"."+name]; ok {
+			return m
+		}
+		i := strings.LastIndex(scope, ".")
+		if i < 0 {
+			return byName[name]
+		}
+		scope = scope[:i]
+	}
+}
+
+// splitComment splits a line into the declaration and the text of a trailing // comment
+func splitComment(line string) (decl, comment string) {
+	decl, comment, _ = strings.Cut(line, "//")
+	return strings.TrimSpace(decl), strings.TrimSpace(comment)
+}
+
+// directive returns the value of a comment like "gotype: Game"
+func directive(comment, name string) (string, bool) {
+	v, ok := strings.CutPrefix(comment, name)
+	return strings.TrimSpace(v), ok
+}
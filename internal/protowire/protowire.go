@@ -0,0 +1,285 @@
+// Package protowire encodes and decodes the protocol buffers wire format, as far as the messages of the
+// Steam service interfaces need it. The code of the messages is generated against it by cmd/steamapi-protogen,
+// see model/IPlayerService.
+package protowire
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Type is the wire type of a field
+type Type int
+
+const (
+	Varint  Type = 0
+	Fixed64 Type = 1
+	Bytes   Type = 2
+	Fixed32 Type = 5
+)
+
+var ErrTruncated = errors.New("protowire: truncated message")
+
+// AppendTag appends the key of the field num with the wire type typ
+func AppendTag(b []byte, num int, typ Type) []byte {
+	return AppendVarint(b, uint64(num)<<3|uint64(typ))
+}
+
+// AppendVarint appends v as a base 128 varint
+func AppendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+// AppendBytes appends v prefixed with its length
+func AppendBytes(b []byte, v []byte) []byte {
+	b = AppendVarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// AppendFixed32 appends v in little-endian byte order
+func AppendFixed32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+// AppendFixed64 appends v in little-endian byte order
+func AppendFixed64(b []byte, v uint64) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24), byte(v>>32), byte(v>>40), byte(v>>48), byte(v>>56))
+}
+
+// AppendVarintField appends the field num with the value v; zero values are left out like proto3 does
+func AppendVarintField(b []byte, num int, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	return AppendVarint(AppendTag(b, num, Varint), v)
+}
+
+// AppendBoolField appends the field num with the value v; false is left out
+func AppendBoolField(b []byte, num int, v bool) []byte {
+	if !v {
+		return b
+	}
+	return AppendVarint(AppendTag(b, num, Varint), 1)
+}
+
+// AppendStringField appends the field num with the value v; empty strings are left out
+func AppendStringField(b []byte, num int, v string) []byte {
+	if v == "" {
+		return b
+	}
+	return AppendBytes(AppendTag(b, num, Bytes), []byte(v))
+}
+
+// AppendMessageField appends the encoded message msg as the field num
+func AppendMessageField(b []byte, num int, msg []byte) []byte {
+	return AppendBytes(AppendTag(b, num, Bytes), msg)
+}
+
+/*
+Reader reads the fields of an encoded message:
+
+	r := protowire.NewReader(b)
+	for r.Next() {
+		switch r.Num() {
+		case 1:
+			m.AppId = uint32(r.Varint())
+		default:
+			r.Skip()
+		}
+	}
+	return r.Err()
+
+Every field has to be read or skipped before Next is called again. Errors stop the iteration and are kept
+until Err is called.
+*/
+type Reader struct {
+	buf  []byte
+	num  int
+	typ  Type
+	read bool
+	err  error
+}
+
+// NewReader returns a Reader for the encoded message b
+func NewReader(b []byte) *Reader {
+	return &Reader{buf: b, read: true}
+}
+
+// Next reads the key of the next field; it returns false at the end of the message or after an error
+func (r *Reader) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	if !r.read {
+		r.Skip()
+		if r.err != nil {
+			return false
+		}
+	}
+	if len(r.buf) == 0 {
+		return false
+	}
+	key := r.varint()
+	if r.err != nil {
+		return false
+	}
+	r.num = int(key >> 3)
+	r.typ = Type(key & 7)
+	r.read = false
+	if r.num <= 0 {
+		r.err = fmt.Errorf("protowire: invalid field number %d", r.num)
+		return false
+	}
+	return true
+}
+
+// Num returns the number of the current field
+func (r *Reader) Num() int {
+	return r.num
+}
+
+// Type returns the wire type of the current field
+func (r *Reader) Type() Type {
+	return r.typ
+}
+
+// Varint reads the current field as a varint
+func (r *Reader) Varint() uint64 {
+	if !r.expect(Varint) {
+		return 0
+	}
+	return r.varint()
+}
+
+// Bool reads the current field as a bool
+func (r *Reader) Bool() bool {
+	return r.Varint() != 0
+}
+
+// Bytes reads the current field as length-delimited bytes. The result points into the message.
+func (r *Reader) Bytes() []byte {
+	if !r.expect(Bytes) {
+		return nil
+	}
+	n := r.varint()
+	if r.err != nil {
+		return nil
+	}
+	if n > uint64(len(r.buf)) {
+		r.err = ErrTruncated
+		return nil
+	}
+	v := r.buf[:n]
+	r.buf = r.buf[n:]
+	return v
+}
+
+// String reads the current field as a string
+func (r *Reader) String() string {
+	return string(r.Bytes())
+}
+
+// Fixed32 reads the current field as 4 little-endian bytes
+func (r *Reader) Fixed32() uint32 {
+	if !r.expect(Fixed32) {
+		return 0
+	}
+	if len(r.buf) < 4 {
+		r.err = ErrTruncated
+		return 0
+	}
+	v := uint32(r.buf[0]) | uint32(r.buf[1])<<8 | uint32(r.buf[2])<<16 | uint32(r.buf[3])<<24
+	r.buf = r.buf[4:]
+	return v
+}
+
+// Fixed64 reads the current field as 8 little-endian bytes
+func (r *Reader) Fixed64() uint64 {
+	if !r.expect(Fixed64) {
+		return 0
+	}
+	if len(r.buf) < 8 {
+		r.err = ErrTruncated
+		return 0
+	}
+	var v uint64
+	for i := 7; i >= 0; i-- {
+		v = v<<8 | uint64(r.buf[i])
+	}
+	r.buf = r.buf[8:]
+	return v
+}
+
+// Varints reads a repeated varint field, which can be sent packed or as a single value per field.
+// The values are appended to list.
+func (r *Reader) Varints(list []uint64) []uint64 {
+	if r.typ != Bytes {
+		return append(list, r.Varint())
+	}
+	packed := NewReader(r.Bytes())
+	for r.err == nil && len(packed.buf) > 0 {
+		list = append(list, packed.varint())
+		r.err = packed.err
+	}
+	return list
+}
+
+// Skip skips the current field
+func (r *Reader) Skip() {
+	switch r.typ {
+	case Varint:
+		r.Varint()
+	case Fixed64:
+		r.Fixed64()
+	case Bytes:
+		r.Bytes()
+	case Fixed32:
+		r.Fixed32()
+	default:
+		r.err = fmt.Errorf("protowire: unsupported wire type %d of field %d", r.typ, r.num)
+	}
+}
+
+// Err returns the first error that occurred
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// expect marks the current field as read and checks its wire type
+func (r *Reader) expect(typ Type) bool {
+	if r.err != nil {
+		return false
+	}
+	if r.read {
+		r.err = errors.New("protowire: field already read")
+		return false
+	}
+	r.read = true
+	if r.typ != typ {
+		r.err = fmt.Errorf("protowire: field %d has wire type %d, not %d", r.num, r.typ, typ)
+		return false
+	}
+	return true
+}
+
+func (r *Reader) varint() uint64 {
+	var v uint64
+	for i := 0; i < len(r.buf) && i < 10; i++ {
+		b := r.buf[i]
+		v |= uint64(b&0x7f) << (7 * i)
+		if b < 0x80 {
+			r.buf = r.buf[i+1:]
+			return v
+		}
+	}
+	if len(r.buf) < 10 {
+		r.err = ErrTruncated
+	} else {
+		r.err = errors.New("protowire: varint overflows 64 bits")
+	}
+	return 0
+}
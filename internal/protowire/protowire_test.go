@@ -0,0 +1,88 @@
+package protowire
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppend(t *testing.T) {
+	assert.Equal(t, []byte{0x00}, AppendVarint(nil, 0))
+	assert.Equal(t, []byte{0x96, 0x01}, AppendVarint(nil, 150))
+	assert.Equal(t, []byte{0x08, 0x96, 0x01}, AppendVarintField(nil, 1, 150))
+	assert.Empty(t, AppendVarintField(nil, 1, 0))
+	assert.Equal(t, []byte{0x12, 0x07, 't', 'e', 's', 't', 'i', 'n', 'g'}, AppendStringField(nil, 2, "testing"))
+	assert.Empty(t, AppendStringField(nil, 2, ""))
+	assert.Equal(t, []byte{0x18, 0x01}, AppendBoolField(nil, 3, true))
+	assert.Equal(t, []byte{0x78, 0x01}, AppendVarint(AppendTag(nil, 15, Varint), 1))
+	assert.Equal(t, []byte{0x01, 0x02, 0x03, 0x04}, AppendFixed32(nil, 0x04030201))
+	assert.Equal(t, []byte{0x01, 0, 0, 0, 0, 0, 0, 0x10}, AppendFixed64(nil, 0x1000000000000001))
+	assert.Equal(t, []byte{0x22, 0x02, 0x08, 0x01}, AppendMessageField(nil, 4, AppendVarintField(nil, 1, 1)))
+}
+
+func TestReader(t *testing.T) {
+	var b []byte
+	b = AppendVarintField(b, 1, 76561197960435530)
+	b = AppendStringField(b, 2, "Team Fortress 2")
+	b = AppendFixed32(AppendTag(b, 3, Fixed32), 7)
+	b = AppendFixed64(AppendTag(b, 4, Fixed64), 8)
+	b = AppendMessageField(b, 5, AppendVarintField(nil, 1, 440))
+	b = AppendVarintField(b, 6, 1)
+	b = AppendVarintField(b, 6, 2)
+	b = AppendBytes(AppendTag(b, 6, Bytes), []byte{0x03, 0x96, 0x01})
+	b = AppendVarintField(b, 99, 1)
+
+	var ids []uint64
+	r := NewReader(b)
+	for r.Next() {
+		switch r.Num() {
+		case 1:
+			assert.Equal(t, uint64(76561197960435530), r.Varint())
+		case 2:
+			assert.Equal(t, "Team Fortress 2", r.String())
+		case 3:
+			assert.Equal(t, uint32(7), r.Fixed32())
+		case 4:
+			assert.Equal(t, uint64(8), r.Fixed64())
+		case 5:
+			inner := NewReader(r.Bytes())
+			assert.True(t, inner.Next())
+			assert.Equal(t, uint64(440), inner.Varint())
+			assert.False(t, inner.Next())
+			assert.NoError(t, inner.Err())
+		case 6:
+			ids = r.Varints(ids)
+		default:
+			// unknown fields are skipped by Next
+		}
+	}
+	assert.NoError(t, r.Err())
+	assert.Equal(t, []uint64{1, 2, 3, 150}, ids)
+}
+
+func TestReaderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+		read func(r *Reader)
+	}{
+		{name: "truncated varint", b: []byte{0x08, 0x96}, read: func(r *Reader) { r.Varint() }},
+		{name: "truncated bytes", b: []byte{0x12, 0x05, 'a'}, read: func(r *Reader) { r.Bytes() }},
+		{name: "truncated fixed32", b: []byte{0x1d, 0x01}, read: func(r *Reader) { r.Fixed32() }},
+		{name: "wrong wire type", b: []byte{0x08, 0x01}, read: func(r *Reader) { r.Bytes() }},
+		{name: "read twice", b: []byte{0x08, 0x01}, read: func(r *Reader) { r.Varint(); r.Varint() }},
+		{name: "field number 0", b: []byte{0x00, 0x01}, read: func(r *Reader) {}},
+		{name: "group", b: []byte{0x0b, 0x0c}, read: func(r *Reader) { r.Skip() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReader(tt.b)
+			for r.Next() {
+				tt.read(r)
+			}
+			assert.Error(t, r.Err())
+			assert.False(t, r.Next(), "a failed reader stays failed")
+		})
+	}
+}
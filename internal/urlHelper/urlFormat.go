@@ -3,6 +3,7 @@ package urlhelper
 import (
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/xemkayx/steam-api/pkg/steamclient/constant"
 )
@@ -14,5 +15,10 @@ type VersionedURLEndpoint struct {
 
 // format the steam url based on interface, endpoint, version and queries
 func RequestURLFormatter(interf string, urlEndpoint VersionedURLEndpoint, query url.Values) string {
-	return fmt.Sprintf("%s/%s/%s/v%s?%s", constant.SteamWebApiBaseURL, interf, urlEndpoint.EndpointPath, urlEndpoint.Version, query.Encode())
+	return RequestURLFormatterWithBase(constant.SteamWebApiBaseURL, interf, urlEndpoint, query)
+}
+
+// format the url based on a base url (e.g. a proxy or the partner host), interface, endpoint, version and queries
+func RequestURLFormatterWithBase(baseURL string, interf string, urlEndpoint VersionedURLEndpoint, query url.Values) string {
+	return fmt.Sprintf("%s/%s/%s/v%s?%s", strings.TrimSuffix(baseURL, "/"), interf, urlEndpoint.EndpointPath, urlEndpoint.Version, query.Encode())
 }
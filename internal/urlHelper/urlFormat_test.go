@@ -25,3 +25,14 @@ func TestRequestURLFormatter(t *testing.T) {
 		assert.Equal(t, expectedURL, result, "The URLs should be the same")
 	})
 }
+
+func TestRequestURLFormatterWithBase(t *testing.T) {
+	endPoint := VersionedURLEndpoint{EndpointPath: "SetUserStatsForGame", Version: "1"}
+	urlValues := url.Values{}
+	urlValues.Set("appid", "440")
+
+	for _, base := range []string{"https://partner.steam-api.com", "https://partner.steam-api.com/"} {
+		result := RequestURLFormatterWithBase(base, "ISteamUserStats", endPoint, urlValues)
+		assert.Equal(t, "https://partner.steam-api.com/ISteamUserStats/SetUserStatsForGame/v1?appid=440", result)
+	}
+}
@@ -6,10 +6,9 @@ import (
 	"fmt"
 
 	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+	"github.com/xemkayx/steam-api/pkg/vdf"
 )
 
-// todo: pretty print for vdf
-
 // PrettyPrints a returned object in the given format
 func PrettyPrint(v interface{}, format config.OutputFormat) string {
 	switch format {
@@ -17,6 +16,8 @@ func PrettyPrint(v interface{}, format config.OutputFormat) string {
 		return prettyPrintJSON(v)
 	case config.Xml:
 		return prettyPrintXML(v)
+	case config.Vdf:
+		return prettyPrintVDF(v)
 	default:
 		return fmt.Sprintf("Unsupported format: %s", format)
 	}
@@ -39,3 +40,12 @@ func prettyPrintXML(v interface{}) string {
 	}
 	return string(data)
 }
+
+// pretty-prints a VDF (KeyValues) object
+func prettyPrintVDF(v interface{}) string {
+	data, err := vdf.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return fmt.Sprintf("Error printing object as VDF: %v", err)
+	}
+	return string(data)
+}
@@ -54,6 +54,27 @@ func TestPrettyPrintXML(t *testing.T) {
 	}
 }
 
+func TestPrettyPrintVDF(t *testing.T) {
+	input := struct {
+		Platform Platform `vdf:"platform"`
+	}{
+		Platform: Platform{
+			Name: "Steam",
+			Type: "Platform",
+		},
+	}
+
+	expectedOutput := `"platform"
+{
+	"Name"		"Steam"
+	"Type"		"Platform"
+}`
+	output := PrettyPrint(input, config.Vdf)
+	if output != expectedOutput {
+		t.Errorf("Expected output: %s, got: %s", expectedOutput, output)
+	}
+}
+
 func TestPrettyPrintUnsupportedFormat(t *testing.T) {
 	input := map[string]interface{}{
 		"name": "Steam",
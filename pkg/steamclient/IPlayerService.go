@@ -1,27 +1,33 @@
 package steamclient
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"log"
+	"math"
 	"net/url"
 	"strconv"
 
 	urlHelper "github.com/xemkayx/steam-api/internal/urlHelper"
 	"github.com/xemkayx/steam-api/pkg/steamclient/config"
 	model "github.com/xemkayx/steam-api/pkg/steamclient/model/IPlayerService"
+	"github.com/xemkayx/steam-api/pkg/steamid"
 )
 
 const (
-	IPlayerService                 = "IPlayerService"
-	GetOwnedGamesEndpoint          = "GetOwnedGames"          // v0001
-	GetRecentlyPlayedGamesEndpoint = "GetRecentlyPlayedGames" // v0001
+	IPlayerService                    = "IPlayerService"
+	GetOwnedGamesEndpoint             = "GetOwnedGames"             // v0001
+	GetRecentlyPlayedGamesEndpoint    = "GetRecentlyPlayedGames"    // v0001
+	GetSteamLevelEndpoint             = "GetSteamLevel"             // v0001
+	GetBadgesEndpoint                 = "GetBadges"                 // v0001
+	GetCommunityBadgeProgressEndpoint = "GetCommunityBadgeProgress" // v0001
+	IsPlayingSharedGameEndpoint       = "IsPlayingSharedGame"       // v0001
 )
 
 // Parameters for the GetOwnedGames method
 type GetOwnedGamesParams struct {
-	SteamId                int64               // The player we're asking about
+	SteamId                steamid.ID          // The player we're asking about
 	IncludeAppinfo         bool                // true if we want additional details (name, icon) about each game
 	IncludePlayedFreeGames bool                // Free games are excluded by default. If this is set, free games the user has played will be returned.
 	Format                 config.OutputFormat // Format of the output
@@ -31,11 +37,37 @@ type GetOwnedGamesParams struct {
 
 // Parameters for the GetRecentlyPlayedGames method
 type GetRecentlyPlayedGamesParams struct {
-	SteamId int64               // The player we're asking about
+	SteamId steamid.ID          // The player we're asking about
 	Count   int                 // The number of games to return (0/unset: all)
 	Format  config.OutputFormat // Format of the output
 }
 
+// Parameters for the GetSteamLevel method
+type GetSteamLevelParams struct {
+	SteamId steamid.ID          // The player we're asking about
+	Format  config.OutputFormat // Format of the output
+}
+
+// Parameters for the GetBadges method
+type GetBadgesParams struct {
+	SteamId steamid.ID          // The player we're asking about
+	Format  config.OutputFormat // Format of the output
+}
+
+// Parameters for the GetCommunityBadgeProgress method
+type GetCommunityBadgeProgressParams struct {
+	SteamId steamid.ID          // The player we're asking about
+	BadgeId int32               // The badge we're asking about
+	Format  config.OutputFormat // Format of the output
+}
+
+// Parameters for the IsPlayingSharedGame method
+type IsPlayingSharedGameParams struct {
+	SteamId      steamid.ID          // The player we're asking about
+	AppIdPlaying uint32              // The game the player is currently playing
+	Format       config.OutputFormat // Format of the output
+}
+
 /*
 GetOwnedGames returns a list of games a player owns along with some playtime information, if the profile is publicly visible.
 Private, friends-only, and other privacy settings are not supported unless you are asking for your own personal details
@@ -53,46 +85,66 @@ Arguments
     If include_played_free_games is set, they will be returned if the player has played them at some point.
     This is the same behavior as the games list on the Steam Community.
   - format
-    Output format. json (default), xml or vdf.
+    Output format. json (default), xml, vdf or protobuf_raw. protobuf_raw has fields the other formats leave out.
   - appids_filter
     You can optionally filter the list to a set of appids.
 */
 func (c Client) GetOwnedGames(params GetOwnedGamesParams) (*model.OwnedGames, error) {
+	return c.GetOwnedGamesCtx(context.Background(), params)
+}
+
+// GetOwnedGamesCtx is like GetOwnedGames but sends the request with the given context.
+func (c Client) GetOwnedGamesCtx(ctx context.Context, params GetOwnedGamesParams) (*model.OwnedGames, error) {
 	if !c.IsKeySet() {
-		return nil, errors.New(apiKeyErrorMessage)
+		return nil, ErrKeyRequired
 	}
 	version := "1"
 
-	inputJson := map[string]interface{}{
-		"steamid":                   params.SteamId,
-		"include_appinfo":           params.IncludeAppinfo,
-		"include_played_free_games": params.IncludePlayedFreeGames,
-	}
-
-	if len(params.AppIdsFilter) > 0 {
-		inputJson["appids_filter"] = params.AppIdsFilter
-	}
-
-	jsonBytes, err := json.Marshal(inputJson)
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling JSON: %s", err)
-	}
-
 	vals := url.Values{}
 	vals.Set("key", c.Key)
 	vals.Set("format", params.Format.String())
-	vals.Set("input_json", string(jsonBytes))
 
-	if params.Language != nil {
-		vals.Set("l", params.Language.String())
+	if params.Format == config.Protobuf {
+		msg := model.OwnedGamesRequest{
+			SteamId:                params.SteamId.Uint64(),
+			IncludeAppinfo:         params.IncludeAppinfo,
+			IncludePlayedFreeGames: params.IncludePlayedFreeGames,
+		}
+		for _, id := range params.AppIdsFilter {
+			if id < 0 || id > math.MaxUint32 {
+				return nil, fmt.Errorf("app ID %d is out of the range of the protobuf request", id)
+			}
+			msg.AppIdsFilter = append(msg.AppIdsFilter, uint32(id))
+		}
+		if params.Language != nil {
+			msg.Language = params.Language.String()
+		}
+		vals.Set("input_protobuf_encoded", base64.StdEncoding.EncodeToString(msg.MarshalProto()))
+	} else {
+		inputJson := map[string]interface{}{
+			"steamid":                   params.SteamId.Uint64(),
+			"include_appinfo":           params.IncludeAppinfo,
+			"include_played_free_games": params.IncludePlayedFreeGames,
+		}
+
+		if len(params.AppIdsFilter) > 0 {
+			inputJson["appids_filter"] = params.AppIdsFilter
+		}
+
+		jsonBytes, err := json.Marshal(inputJson)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling JSON: %s", err)
+		}
+		vals.Set("input_json", string(jsonBytes))
+
+		if params.Language != nil {
+			vals.Set("l", params.Language.String())
+		}
 	}
 
 	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetOwnedGamesEndpoint, Version: version}
-	url := urlHelper.RequestURLFormatter(IPlayerService, versUrlEndpoint, vals)
-
-	resp, err := c.getRequest(url)
+	resp, err := c.get(ctx, IPlayerService, versUrlEndpoint, vals)
 	if err != nil {
-		log.Fatal(err)
 		return nil, err
 	}
 
@@ -113,8 +165,22 @@ func (c Client) GetOwnedGames(params GetOwnedGamesParams) (*model.OwnedGames, er
 		}
 		return &result, nil
 
+	case config.Vdf:
+		var result model.OwnedGamesWrapper
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.OwnedGames, nil
+
+	case config.Protobuf:
+		var result model.OwnedGames
+		if err := decodeProtobuf(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result, nil
+
 	default:
-		return nil, fmt.Errorf("unsupported format requested: %v", params.Format)
+		return nil, unsupportedFormatError(params.Format)
 	}
 }
 
@@ -131,26 +197,34 @@ Arguments
   - count
     Optionally limit to a certain number of games (the number of games a person has played in the last 2 weeks is typically very small)
   - format
-    Output format. json (default), xml or vdf.
+    Output format. json (default), xml, vdf or protobuf_raw.
 */
 func (c Client) GetRecentlyPlayedGames(params GetRecentlyPlayedGamesParams) (*model.RecentlyPlayedGames, error) {
+	return c.GetRecentlyPlayedGamesCtx(context.Background(), params)
+}
+
+// GetRecentlyPlayedGamesCtx is like GetRecentlyPlayedGames but sends the request with the given context.
+func (c Client) GetRecentlyPlayedGamesCtx(ctx context.Context, params GetRecentlyPlayedGamesParams) (*model.RecentlyPlayedGames, error) {
 	if !c.IsKeySet() {
-		return nil, errors.New(apiKeyErrorMessage)
+		return nil, ErrKeyRequired
 	}
 	version := "1"
 
 	vals := url.Values{}
 	vals.Set("key", c.Key)
-	vals.Set("steamid", strconv.FormatInt(params.SteamId, 10))
-	vals.Set("count", strconv.Itoa(params.Count))
 	vals.Set("format", params.Format.String())
 
-	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetRecentlyPlayedGamesEndpoint, Version: version}
-	url := urlHelper.RequestURLFormatter(IPlayerService, versUrlEndpoint, vals)
+	if params.Format == config.Protobuf {
+		msg := model.RecentlyPlayedGamesRequest{SteamId: params.SteamId.Uint64(), Count: uint32(params.Count)}
+		vals.Set("input_protobuf_encoded", base64.StdEncoding.EncodeToString(msg.MarshalProto()))
+	} else {
+		vals.Set("steamid", params.SteamId.String())
+		vals.Set("count", strconv.Itoa(params.Count))
+	}
 
-	resp, err := c.getRequest(url)
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetRecentlyPlayedGamesEndpoint, Version: version}
+	resp, err := c.get(ctx, IPlayerService, versUrlEndpoint, vals)
 	if err != nil {
-		log.Fatal(err)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -170,9 +244,287 @@ func (c Client) GetRecentlyPlayedGames(params GetRecentlyPlayedGamesParams) (*mo
 		}
 		return &result, nil
 
+	case config.Vdf:
+		var result model.RecentlyPlayedGamesWrapper
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.RecentlyPlayedGames, nil
+
+	case config.Protobuf:
+		var result model.RecentlyPlayedGames
+		if err := decodeProtobuf(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result, nil
+
 	default:
-		return nil, fmt.Errorf("unsupported format requested: %v", params.Format)
+		return nil, unsupportedFormatError(params.Format)
 	}
 }
 
-// TODO: other endpoints
+/*
+GetSteamLevel returns the Steam level of a player, if the profile is publicly visible.
+
+# Key required
+
+Arguments
+  - steamid
+    The SteamID of the account.
+  - format
+    Output format. json (default), xml or vdf.
+*/
+func (c Client) GetSteamLevel(params GetSteamLevelParams) (*model.SteamLevel, error) {
+	return c.GetSteamLevelCtx(context.Background(), params)
+}
+
+// GetSteamLevelCtx is like GetSteamLevel but sends the request with the given context.
+func (c Client) GetSteamLevelCtx(ctx context.Context, params GetSteamLevelParams) (*model.SteamLevel, error) {
+	if !c.IsKeySet() {
+		return nil, ErrKeyRequired
+	}
+	if err := checkTextFormat(params.Format); err != nil {
+		return nil, err
+	}
+	version := "1"
+
+	vals := url.Values{}
+	vals.Set("key", c.Key)
+	vals.Set("steamid", params.SteamId.String())
+	vals.Set("format", params.Format.String())
+
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetSteamLevelEndpoint, Version: version}
+	resp, err := c.get(ctx, IPlayerService, versUrlEndpoint, vals)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch params.Format {
+	case config.Json:
+		var result model.SteamLevelWrapper
+		if _, err := decodeJSON(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.SteamLevel, nil
+
+	case config.Xml:
+		var result model.SteamLevel
+		if _, err := decodeXML(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result, nil
+
+	case config.Vdf:
+		var result model.SteamLevelWrapper
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.SteamLevel, nil
+
+	default:
+		return nil, unsupportedFormatError(params.Format)
+	}
+}
+
+/*
+GetBadges returns the badges of a player together with their XP and level, if the profile is publicly visible.
+LevelForXP and XPForLevel calculate the level curve the XP values are based on.
+
+# Key required
+
+Arguments
+  - steamid
+    The SteamID of the account.
+  - format
+    Output format. json (default), xml or vdf.
+*/
+func (c Client) GetBadges(params GetBadgesParams) (*model.Badges, error) {
+	return c.GetBadgesCtx(context.Background(), params)
+}
+
+// GetBadgesCtx is like GetBadges but sends the request with the given context.
+func (c Client) GetBadgesCtx(ctx context.Context, params GetBadgesParams) (*model.Badges, error) {
+	if !c.IsKeySet() {
+		return nil, ErrKeyRequired
+	}
+	if err := checkTextFormat(params.Format); err != nil {
+		return nil, err
+	}
+	version := "1"
+
+	vals := url.Values{}
+	vals.Set("key", c.Key)
+	vals.Set("steamid", params.SteamId.String())
+	vals.Set("format", params.Format.String())
+
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetBadgesEndpoint, Version: version}
+	resp, err := c.get(ctx, IPlayerService, versUrlEndpoint, vals)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch params.Format {
+	case config.Json:
+		var result model.BadgesWrapper
+		if _, err := decodeJSON(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.Badges, nil
+
+	case config.Xml:
+		var result model.Badges
+		if _, err := decodeXML(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result, nil
+
+	case config.Vdf:
+		var result model.BadgesWrapper
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.Badges, nil
+
+	default:
+		return nil, unsupportedFormatError(params.Format)
+	}
+}
+
+/*
+GetCommunityBadgeProgress returns the quests of a community badge and whether the player completed them,
+if the profile is publicly visible.
+
+# Key required
+
+Arguments
+  - steamid
+    The SteamID of the account.
+  - badgeid
+    The badge we're asking about.
+  - format
+    Output format. json (default), xml or vdf.
+*/
+func (c Client) GetCommunityBadgeProgress(params GetCommunityBadgeProgressParams) (*model.CommunityBadgeProgress, error) {
+	return c.GetCommunityBadgeProgressCtx(context.Background(), params)
+}
+
+// GetCommunityBadgeProgressCtx is like GetCommunityBadgeProgress but sends the request with the given context.
+func (c Client) GetCommunityBadgeProgressCtx(ctx context.Context, params GetCommunityBadgeProgressParams) (*model.CommunityBadgeProgress, error) {
+	if !c.IsKeySet() {
+		return nil, ErrKeyRequired
+	}
+	if err := checkTextFormat(params.Format); err != nil {
+		return nil, err
+	}
+	version := "1"
+
+	vals := url.Values{}
+	vals.Set("key", c.Key)
+	vals.Set("steamid", params.SteamId.String())
+	vals.Set("badgeid", strconv.FormatInt(int64(params.BadgeId), 10))
+	vals.Set("format", params.Format.String())
+
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetCommunityBadgeProgressEndpoint, Version: version}
+	resp, err := c.get(ctx, IPlayerService, versUrlEndpoint, vals)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch params.Format {
+	case config.Json:
+		var result model.CommunityBadgeProgressWrapper
+		if _, err := decodeJSON(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.CommunityBadgeProgress, nil
+
+	case config.Xml:
+		var result model.CommunityBadgeProgress
+		if _, err := decodeXML(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result, nil
+
+	case config.Vdf:
+		var result model.CommunityBadgeProgressWrapper
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.CommunityBadgeProgress, nil
+
+	default:
+		return nil, unsupportedFormatError(params.Format)
+	}
+}
+
+/*
+IsPlayingSharedGame returns the SteamID of the account that lent the game a player is currently playing via
+Family Sharing, or 0 if the player plays an own copy or isn't playing the game. FamilySharingStatus combines it
+with GetOwnedGames.
+
+# Key required
+
+Arguments
+  - steamid
+    The SteamID of the account.
+  - appid_playing
+    The game the player is currently playing.
+  - format
+    Output format. json (default), xml or vdf.
+*/
+func (c Client) IsPlayingSharedGame(params IsPlayingSharedGameParams) (*model.SharedGame, error) {
+	return c.IsPlayingSharedGameCtx(context.Background(), params)
+}
+
+// IsPlayingSharedGameCtx is like IsPlayingSharedGame but sends the request with the given context.
+func (c Client) IsPlayingSharedGameCtx(ctx context.Context, params IsPlayingSharedGameParams) (*model.SharedGame, error) {
+	if !c.IsKeySet() {
+		return nil, ErrKeyRequired
+	}
+	if err := checkTextFormat(params.Format); err != nil {
+		return nil, err
+	}
+	version := "1"
+
+	vals := url.Values{}
+	vals.Set("key", c.Key)
+	vals.Set("steamid", params.SteamId.String())
+	vals.Set("appid_playing", strconv.FormatUint(uint64(params.AppIdPlaying), 10))
+	vals.Set("format", params.Format.String())
+
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: IsPlayingSharedGameEndpoint, Version: version}
+	resp, err := c.get(ctx, IPlayerService, versUrlEndpoint, vals)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch params.Format {
+	case config.Json:
+		var result model.SharedGameWrapper
+		if _, err := decodeJSON(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.SharedGame, nil
+
+	case config.Xml:
+		var result model.SharedGame
+		if _, err := decodeXML(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result, nil
+
+	case config.Vdf:
+		var result model.SharedGameWrapper
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.SharedGame, nil
+
+	default:
+		return nil, unsupportedFormatError(params.Format)
+	}
+}
@@ -1,8 +1,12 @@
 package steamclient
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"testing"
@@ -74,7 +78,7 @@ func TestGetOwnedGames(t *testing.T) {
 			httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/GetOwnedGames/v1",
 				func(req *http.Request) (*http.Response, error) {
 					inputJson := map[string]interface{}{
-						"steamid":                   tc.params.SteamId,
+						"steamid":                   tc.params.SteamId.Uint64(),
 						"include_appinfo":           tc.params.IncludeAppinfo,
 						"include_played_free_games": tc.params.IncludePlayedFreeGames,
 					}
@@ -151,6 +155,48 @@ func TestGetRecentlyPlayedGames(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Test Case 2 - VDF",
+			params: GetRecentlyPlayedGamesParams{
+				SteamId: 123456789,
+				Count:   5,
+				Format:  config.Vdf,
+			},
+			response: `"response"
+			{
+				"total_count"		"1"
+				"games"
+				{
+					"0"
+					{
+						"appid"		"730"
+						"name"		"Counter-Strike 2"
+						"playtime_2weeks"		"120"
+						"playtime_forever"		"9000"
+						"playtime_windows_forever"		"9000"
+						"content_descriptorids"
+						{
+							"0"		"2"
+							"1"		"5"
+						}
+					}
+				}
+			}`,
+			want: &model.RecentlyPlayedGames{
+				TotalCount: 1,
+				Games: []model.Game{
+					{
+						AppID:                  730,
+						Name:                   "Counter-Strike 2",
+						Playtime2Weeks:         120,
+						PlaytimeForever:        9000,
+						PlaytimeWindowsForever: 9000,
+						ContentDescriptorIds:   []uint32{2, 5},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -158,7 +204,7 @@ func TestGetRecentlyPlayedGames(t *testing.T) {
 			httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/GetRecentlyPlayedGames/v1",
 				func(req *http.Request) (*http.Response, error) {
 					if req.URL.Query().Get("key") != "test-key" ||
-						req.URL.Query().Get("steamid") != strconv.FormatInt(tc.params.SteamId, 10) ||
+						req.URL.Query().Get("steamid") != tc.params.SteamId.String() ||
 						req.URL.Query().Get("count") != strconv.Itoa(tc.params.Count) ||
 						req.URL.Query().Get("format") != tc.params.Format.String() {
 						t.Errorf("Request parameters do not match")
@@ -183,3 +229,377 @@ func TestGetRecentlyPlayedGames(t *testing.T) {
 
 	}
 }
+
+func TestGetOwnedGamesProtobuf(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	fixture, err := os.ReadFile(filepath.Join("testdata", "owned_games.pb"))
+	assert.NoError(t, err)
+	language := config.Language(config.German)
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/GetOwnedGames/v1",
+		func(req *http.Request) (*http.Response, error) {
+			// the language is field 7 of the message, not the l parameter
+			assert.Equal(t, url.Values{
+				"key":                    {"test-key"},
+				"format":                 {"protobuf_raw"},
+				"input_protobuf_encoded": {"CMquioCQgICIARABGAEguAMgkAM6Bmdlcm1hbg=="},
+			}, req.URL.Query())
+			return httpmock.NewBytesResponse(200, fixture), nil
+		})
+
+	got, err := New("test-key", &http.Client{}).GetOwnedGames(GetOwnedGamesParams{
+		SteamId:                76561197960435530,
+		IncludeAppinfo:         true,
+		IncludePlayedFreeGames: true,
+		AppIdsFilter:           []int64{440, 400},
+		Language:               &language,
+		Format:                 config.Protobuf,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &model.OwnedGames{
+		GameCount: 2,
+		Games: []model.Game{
+			{
+				AppID:                    440,
+				Name:                     "Team Fortress 2",
+				Playtime2Weeks:           95,
+				PlaytimeForever:          123456,
+				ImageIconURL:             "e3f595a92552da3d664ad00277fad2107345f743",
+				HasCommunityVisibleStats: true,
+				PlaytimeWindowsForever:   120000,
+				PlaytimeMacForever:       456,
+				PlaytimeLinuxForever:     3000,
+				RtimeLastPlayed:          1714000000,
+				CapsuleFilename:          "capsule_184x69.jpg",
+				SortAs:                   "team fortress 2",
+				HasWorkshop:              true,
+				HasMarket:                true,
+				HasDLC:                   true,
+				ContentDescriptorIds:     []uint32{2, 5},
+				PlaytimeDisconnected:     12,
+			},
+			{
+				AppID:                  400,
+				Name:                   "Portal",
+				PlaytimeForever:        600,
+				ImageIconURL:           "cfa928ab4119dd137e50d728e8fe703e4e970aff",
+				PlaytimeWindowsForever: 600,
+				RtimeLastPlayed:        1600000000,
+				HasLeaderboards:        true,
+				ContentDescriptorIds:   []uint32{1, 5},
+			},
+		},
+	}, got)
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/GetOwnedGames/v1",
+		httpmock.NewBytesResponder(200, fixture[:len(fixture)-3]))
+	_, err = New("test-key", &http.Client{}).GetOwnedGames(GetOwnedGamesParams{SteamId: 76561197960435530, Format: config.Protobuf})
+	assert.Error(t, err, "a truncated message is an error")
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+
+	for _, id := range []int64{-1, 1 << 32} {
+		_, err = New("test-key", &http.Client{}).GetOwnedGames(GetOwnedGamesParams{
+			SteamId:      76561197960435530,
+			AppIdsFilter: []int64{440, id},
+			Format:       config.Protobuf,
+		})
+		assert.ErrorContains(t, err, "out of the range", "app ID %d doesn't fit into the uint32 of the message", id)
+	}
+	assert.Equal(t, 2, httpmock.GetTotalCallCount(), "invalid app IDs are rejected before sending")
+}
+
+func TestGetRecentlyPlayedGamesProtobuf(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	fixture, err := os.ReadFile(filepath.Join("testdata", "recently_played_games.pb"))
+	assert.NoError(t, err)
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/GetRecentlyPlayedGames/v1",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, url.Values{
+				"key":                    {"test-key"},
+				"format":                 {"protobuf_raw"},
+				"input_protobuf_encoded": {"CMquioCQgICIARAF"},
+			}, req.URL.Query())
+			return httpmock.NewBytesResponse(200, fixture), nil
+		})
+
+	got, err := New("test-key", &http.Client{}).GetRecentlyPlayedGames(GetRecentlyPlayedGamesParams{
+		SteamId: 76561197960435530,
+		Count:   5,
+		Format:  config.Protobuf,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &model.RecentlyPlayedGames{
+		TotalCount: 4,
+		Games: []model.Game{{
+			AppID:                  553850,
+			Name:                   "HELLDIVERS™ 2",
+			Playtime2Weeks:         338,
+			PlaytimeForever:        2534,
+			ImageIconURL:           "c3dff088e090f81d6e3d88eabbb67732647c69cf",
+			PlaytimeWindowsForever: 2534,
+			PlaytimeDeckForever:    120,
+		}},
+	}, got)
+}
+
+func TestCallProtobuf(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	fixture, err := os.ReadFile(filepath.Join("testdata", "recently_played_games.pb"))
+	assert.NoError(t, err)
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/GetRecentlyPlayedGames/v1",
+		httpmock.NewBytesResponder(200, fixture))
+
+	client := New("test-key", &http.Client{})
+	msg := model.RecentlyPlayedGamesRequest{SteamId: 76561197960435530}
+	req := NewRequest(IPlayerService, GetRecentlyPlayedGamesEndpoint, 1).Format(config.Protobuf).InputProtobuf(msg.MarshalProto())
+
+	var games model.RecentlyPlayedGames
+	assert.NoError(t, client.Do(context.Background(), req, &games))
+	assert.Equal(t, 4, games.TotalCount)
+
+	var raw []byte
+	assert.NoError(t, client.Do(context.Background(), req, &raw))
+	assert.Equal(t, fixture, raw)
+
+	var out map[string]any
+	assert.Error(t, client.Do(context.Background(), req, &out))
+
+	req = NewRequest(ISteamUserStats, "GetSchemaForGame", 2).Format(config.Protobuf)
+	assert.ErrorIs(t, client.Do(context.Background(), req, nil), ErrUnsupportedFormat)
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+func TestGetSteamLevel(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	tests := []struct {
+		name     string
+		format   config.OutputFormat
+		response string
+	}{
+		{name: "JSON", format: config.Json, response: `{"response":{"player_level":42}}`},
+		{name: "XML", format: config.Xml, response: `<?xml version="1.0" encoding="UTF-8"?>
+			<!DOCTYPE response>
+			<response>
+				<player_level>42</player_level>
+			</response>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/GetSteamLevel/v1",
+				func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, url.Values{
+						"key":     {"test-key"},
+						"steamid": {"76561197960435530"},
+						"format":  {tt.format.String()},
+					}, req.URL.Query())
+					return httpmock.NewStringResponse(200, tt.response), nil
+				})
+
+			got, err := New("test-key", &http.Client{}).GetSteamLevel(GetSteamLevelParams{SteamId: 76561197960435530, Format: tt.format})
+			assert.NoError(t, err)
+			assert.Equal(t, &model.SteamLevel{PlayerLevel: 42}, got)
+		})
+	}
+
+	_, err := NewClientWithoutKey(&http.Client{}).GetSteamLevel(GetSteamLevelParams{})
+	assert.ErrorIs(t, err, ErrKeyRequired)
+}
+
+func TestGetBadges(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	want := &model.Badges{
+		Badges: []model.Badge{
+			{BadgeId: 13, Level: 512, CompletionTime: 1712345678, XP: 762, Scarcity: 1234567},
+			{BadgeId: 1, Level: 5, CompletionTime: 1512345678, XP: 100, Scarcity: 98765, AppId: 440, CommunityItemId: "1234567890", BorderColor: 1},
+		},
+		PlayerXP:                   11120,
+		PlayerLevel:                42,
+		PlayerXPNeededToLevelUp:    380,
+		PlayerXPNeededCurrentLevel: 11000,
+	}
+
+	tests := []struct {
+		name     string
+		format   config.OutputFormat
+		response string
+	}{
+		{name: "JSON", format: config.Json, response: `{"response":{"badges":[
+			{"badgeid":13,"level":512,"completion_time":1712345678,"xp":762,"scarcity":1234567},
+			{"badgeid":1,"level":5,"completion_time":1512345678,"xp":100,"scarcity":98765,"appid":440,"communityitemid":"1234567890","border_color":1}],
+			"player_xp":11120,"player_level":42,"player_xp_needed_to_level_up":380,"player_xp_needed_current_level":11000}}`},
+		{name: "XML", format: config.Xml, response: `<?xml version="1.0" encoding="UTF-8"?>
+			<!DOCTYPE response>
+			<response>
+				<badges>
+					<message><badgeid>13</badgeid><level>512</level><completion_time>1712345678</completion_time><xp>762</xp><scarcity>1234567</scarcity></message>
+					<message><badgeid>1</badgeid><level>5</level><completion_time>1512345678</completion_time><xp>100</xp><scarcity>98765</scarcity><appid>440</appid><communityitemid>1234567890</communityitemid><border_color>1</border_color></message>
+				</badges>
+				<player_xp>11120</player_xp>
+				<player_level>42</player_level>
+				<player_xp_needed_to_level_up>380</player_xp_needed_to_level_up>
+				<player_xp_needed_current_level>11000</player_xp_needed_current_level>
+			</response>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/GetBadges/v1",
+				func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, url.Values{
+						"key":     {"test-key"},
+						"steamid": {"76561197960435530"},
+						"format":  {tt.format.String()},
+					}, req.URL.Query())
+					return httpmock.NewStringResponse(200, tt.response), nil
+				})
+
+			got, err := New("test-key", &http.Client{}).GetBadges(GetBadgesParams{SteamId: 76561197960435530, Format: tt.format})
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+
+			assert.Equal(t, got.PlayerLevel, LevelForXP(got.PlayerXP))
+			assert.Equal(t, got.PlayerXPNeededCurrentLevel, XPForLevel(got.PlayerLevel))
+			assert.Equal(t, got.PlayerXPNeededToLevelUp, XPToNextLevel(got.PlayerXP))
+		})
+	}
+
+	_, err := NewClientWithoutKey(&http.Client{}).GetBadges(GetBadgesParams{})
+	assert.ErrorIs(t, err, ErrKeyRequired)
+}
+
+func TestGetCommunityBadgeProgress(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	want := &model.CommunityBadgeProgress{
+		Quests: []model.Quest{
+			{QuestId: 115, Completed: true},
+			{QuestId: 116, Completed: false},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   config.OutputFormat
+		response string
+	}{
+		{name: "JSON", format: config.Json, response: `{"response":{"quests":[{"questid":115,"completed":true},{"questid":116,"completed":false}]}}`},
+		{name: "XML", format: config.Xml, response: `<?xml version="1.0" encoding="UTF-8"?>
+			<!DOCTYPE response>
+			<response>
+				<quests>
+					<message><questid>115</questid><completed>true</completed></message>
+					<message><questid>116</questid><completed>false</completed></message>
+				</quests>
+			</response>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/GetCommunityBadgeProgress/v1",
+				func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, url.Values{
+						"key":     {"test-key"},
+						"steamid": {"76561197960435530"},
+						"badgeid": {"2"},
+						"format":  {tt.format.String()},
+					}, req.URL.Query())
+					return httpmock.NewStringResponse(200, tt.response), nil
+				})
+
+			got, err := New("test-key", &http.Client{}).GetCommunityBadgeProgress(GetCommunityBadgeProgressParams{
+				SteamId: 76561197960435530,
+				BadgeId: 2,
+				Format:  tt.format,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	_, err := NewClientWithoutKey(&http.Client{}).GetCommunityBadgeProgress(GetCommunityBadgeProgressParams{})
+	assert.ErrorIs(t, err, ErrKeyRequired)
+}
+
+func TestBadgeMethodsRejectProtobuf(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	for _, endpoint := range []string{GetSteamLevelEndpoint, GetBadgesEndpoint, GetCommunityBadgeProgressEndpoint} {
+		httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/"+endpoint+"/v1",
+			httpmock.NewStringResponder(200, ""))
+	}
+
+	client := New("test-key", &http.Client{})
+	_, err := client.GetSteamLevel(GetSteamLevelParams{SteamId: 76561197960435530, Format: config.Protobuf})
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	_, err = client.GetBadges(GetBadgesParams{SteamId: 76561197960435530, Format: config.Protobuf})
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	_, err = client.GetCommunityBadgeProgress(GetCommunityBadgeProgressParams{SteamId: 76561197960435530, Format: config.Protobuf})
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	assert.Equal(t, 0, httpmock.GetTotalCallCount(), "formats without a model are rejected before sending")
+}
+
+func TestIsPlayingSharedGame(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	tests := []struct {
+		name     string
+		format   config.OutputFormat
+		response string
+	}{
+		{name: "JSON", format: config.Json, response: `{"response":{"lender_steamid":"76561197960287930"}}`},
+		{name: "XML", format: config.Xml, response: `<?xml version="1.0" encoding="UTF-8"?>
+			<!DOCTYPE response>
+			<response>
+				<lender_steamid>76561197960287930</lender_steamid>
+			</response>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/IsPlayingSharedGame/v1",
+				func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, url.Values{
+						"key":           {"test-key"},
+						"steamid":       {"76561197960435530"},
+						"appid_playing": {"440"},
+						"format":        {tt.format.String()},
+					}, req.URL.Query())
+					return httpmock.NewStringResponse(200, tt.response), nil
+				})
+
+			got, err := New("test-key", &http.Client{}).IsPlayingSharedGame(IsPlayingSharedGameParams{
+				SteamId:      76561197960435530,
+				AppIdPlaying: 440,
+				Format:       tt.format,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, &model.SharedGame{LenderSteamId: 76561197960287930}, got)
+		})
+	}
+
+	_, err := NewClientWithoutKey(&http.Client{}).IsPlayingSharedGame(IsPlayingSharedGameParams{})
+	assert.ErrorIs(t, err, ErrKeyRequired)
+
+	httpmock.ZeroCallCounters()
+	_, err = New("test-key", &http.Client{}).IsPlayingSharedGame(IsPlayingSharedGameParams{
+		SteamId:      76561197960435530,
+		AppIdPlaying: 440,
+		Format:       config.Protobuf,
+	})
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	assert.Equal(t, 0, httpmock.GetTotalCallCount(), "protobuf is rejected before sending")
+}
@@ -0,0 +1,219 @@
+package steamclient
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	urlHelper "github.com/xemkayx/steam-api/internal/urlHelper"
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+	model "github.com/xemkayx/steam-api/pkg/steamclient/model/ISteamApps"
+)
+
+const (
+	ISteamApps                  = "ISteamApps"
+	GetAppListEndpoint          = "GetAppList"          // v0002
+	GetServersAtAddressEndpoint = "GetServersAtAddress" // v0001
+	UpToDateCheckEndpoint       = "UpToDateCheck"       // v0001
+)
+
+// Parameters for the GetAppList method
+type GetAppListParams struct {
+	Format config.OutputFormat // Format of the output
+}
+
+// Parameters for the GetServersAtAddress method
+type GetServersAtAddressParams struct {
+	Addr   string              // IP or IP:queryport to list
+	Format config.OutputFormat // Format of the output
+}
+
+// Parameters for the UpToDateCheck method
+type UpToDateCheckParams struct {
+	AppId   uint32              // AppID of game
+	Version uint32              // The installed version of the game
+	Format  config.OutputFormat // Format of the output
+}
+
+/*
+GetAppList returns the AppIDs and names of all apps on Steam. The list is large (several MB), load it once
+into an AppIndex to look up names.
+
+Arguments
+  - format
+    Output format. json (default), xml or vdf.
+*/
+func (c Client) GetAppList(params GetAppListParams) (*model.AppList, error) {
+	return c.GetAppListCtx(context.Background(), params)
+}
+
+// GetAppListCtx is like GetAppList but sends the request with the given context.
+func (c Client) GetAppListCtx(ctx context.Context, params GetAppListParams) (*model.AppList, error) {
+	version := "2"
+
+	vals := url.Values{}
+	vals.Set("format", params.Format.String())
+
+	if c.IsKeySet() {
+		vals.Set("key", c.Key)
+	}
+
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetAppListEndpoint, Version: version}
+	resp, err := c.get(ctx, ISteamApps, versUrlEndpoint, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	switch params.Format {
+	case config.Json:
+		var result model.AppListWrapper
+		if _, err := decodeJSON(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.AppList, nil
+
+	case config.Xml:
+		var result model.AppList
+		if _, err := decodeXML(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result, nil
+
+	case config.Vdf:
+		var result model.AppListWrapper
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.AppList, nil
+
+	default:
+		return nil, unsupportedFormatError(params.Format)
+	}
+}
+
+/*
+GetServersAtAddress returns the game servers running at an IP address.
+If the address is invalid, Success is false and Message tells why.
+
+Arguments
+  - addr
+    IP or IP:queryport to list.
+  - format
+    Output format. json (default), xml or vdf.
+*/
+func (c Client) GetServersAtAddress(params GetServersAtAddressParams) (*model.ServersAtAddress, error) {
+	return c.GetServersAtAddressCtx(context.Background(), params)
+}
+
+// GetServersAtAddressCtx is like GetServersAtAddress but sends the request with the given context.
+func (c Client) GetServersAtAddressCtx(ctx context.Context, params GetServersAtAddressParams) (*model.ServersAtAddress, error) {
+	version := "1"
+
+	vals := url.Values{}
+	vals.Set("addr", params.Addr)
+	vals.Set("format", params.Format.String())
+
+	if c.IsKeySet() {
+		vals.Set("key", c.Key)
+	}
+
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetServersAtAddressEndpoint, Version: version}
+	resp, err := c.get(ctx, ISteamApps, versUrlEndpoint, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	switch params.Format {
+	case config.Json:
+		var result model.ServersAtAddressWrapper
+		if _, err := decodeJSON(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.Response, nil
+
+	case config.Xml:
+		var result model.ServersAtAddress
+		if _, err := decodeXML(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result, nil
+
+	case config.Vdf:
+		var result model.ServersAtAddressWrapper
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.Response, nil
+
+	default:
+		return nil, unsupportedFormatError(params.Format)
+	}
+}
+
+/*
+UpToDateCheck checks if the given version of a game is the current one, e.g. for game servers.
+If it's not, RequiredVersion holds the version to update to.
+
+Arguments
+  - appid
+    AppID of the game.
+  - version
+    The installed version of the game.
+  - format
+    Output format. json (default), xml or vdf.
+*/
+func (c Client) UpToDateCheck(params UpToDateCheckParams) (*model.UpToDateCheck, error) {
+	return c.UpToDateCheckCtx(context.Background(), params)
+}
+
+// UpToDateCheckCtx is like UpToDateCheck but sends the request with the given context.
+func (c Client) UpToDateCheckCtx(ctx context.Context, params UpToDateCheckParams) (*model.UpToDateCheck, error) {
+	version := "1"
+
+	vals := url.Values{}
+	vals.Set("appid", strconv.FormatUint(uint64(params.AppId), 10))
+	vals.Set("version", strconv.FormatUint(uint64(params.Version), 10))
+	vals.Set("format", params.Format.String())
+
+	if c.IsKeySet() {
+		vals.Set("key", c.Key)
+	}
+
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: UpToDateCheckEndpoint, Version: version}
+	resp, err := c.get(ctx, ISteamApps, versUrlEndpoint, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	switch params.Format {
+	case config.Json:
+		var result model.UpToDateCheckWrapper
+		if _, err := decodeJSON(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.Response, nil
+
+	case config.Xml:
+		var result model.UpToDateCheck
+		if _, err := decodeXML(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result, nil
+
+	case config.Vdf:
+		var result model.UpToDateCheckWrapper
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.Response, nil
+
+	default:
+		return nil, unsupportedFormatError(params.Format)
+	}
+}
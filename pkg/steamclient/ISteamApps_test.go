@@ -0,0 +1,218 @@
+package steamclient
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+	model "github.com/xemkayx/steam-api/pkg/steamclient/model/ISteamApps"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAppList(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	want := &model.AppList{Apps: []model.App{
+		{AppId: 10, Name: "Counter-Strike"},
+		{AppId: 440, Name: "Team Fortress 2"},
+	}}
+
+	tests := []struct {
+		name     string
+		format   config.OutputFormat
+		response string
+	}{
+		{name: "JSON", format: config.Json, response: `{"applist":{"apps":[{"appid":10,"name":"Counter-Strike"},{"appid":440,"name":"Team Fortress 2"}]}}`},
+		{name: "XML", format: config.Xml, response: `<?xml version="1.0" encoding="UTF-8"?>
+			<!DOCTYPE applist>
+			<applist>
+				<apps>
+					<app><appid>10</appid><name>Counter-Strike</name></app>
+					<app><appid>440</appid><name>Team Fortress 2</name></app>
+				</apps>
+			</applist>`},
+		{name: "VDF", format: config.Vdf, response: `"applist"
+			{
+				"apps"
+				{
+					"0"
+					{
+						"appid"	"10"
+						"name"	"Counter-Strike"
+					}
+					"1"
+					{
+						"appid"	"440"
+						"name"	"Team Fortress 2"
+					}
+				}
+			}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamApps/GetAppList/v2",
+				func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, tt.format.String(), req.URL.Query().Get("format"))
+					return httpmock.NewStringResponse(200, tt.response), nil
+				})
+
+			client := NewClientWithoutKey(&http.Client{})
+			got, err := client.GetAppList(GetAppListParams{Format: tt.format})
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestGetServersAtAddress(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	tests := []struct {
+		name     string
+		params   GetServersAtAddressParams
+		response string
+		want     *model.ServersAtAddress
+	}{
+		{
+			name:   "JSON",
+			params: GetServersAtAddressParams{Addr: "192.0.2.10", Format: config.Json},
+			response: `{"response":{"success":true,"servers":[{"addr":"192.0.2.10:27015","gmsindex":-1,"steamid":"90178913460052995",
+				"appid":730,"gamedir":"csgo","region":-1,"secure":true,"lan":false,"gameport":27015,"specport":0}]}}`,
+			want: &model.ServersAtAddress{
+				Success: true,
+				Servers: []model.Server{{
+					Addr:     "192.0.2.10:27015",
+					GMSIndex: -1,
+					SteamID:  90178913460052995,
+					AppId:    730,
+					GameDir:  "csgo",
+					Region:   -1,
+					Secure:   true,
+					GamePort: 27015,
+				}},
+			},
+		},
+		{
+			name:   "XML",
+			params: GetServersAtAddressParams{Addr: "192.0.2.10", Format: config.Xml},
+			response: `<?xml version="1.0" encoding="UTF-8"?>
+				<!DOCTYPE response>
+				<response>
+					<success>true</success>
+					<servers>
+						<server>
+							<addr>192.0.2.10:27015</addr>
+							<gmsindex>-1</gmsindex>
+							<steamid>90178913460052995</steamid>
+							<appid>730</appid>
+							<gamedir>csgo</gamedir>
+							<region>-1</region>
+							<secure>true</secure>
+							<lan>false</lan>
+							<gameport>27015</gameport>
+							<specport>0</specport>
+						</server>
+					</servers>
+				</response>`,
+			want: &model.ServersAtAddress{
+				Success: true,
+				Servers: []model.Server{{
+					Addr:     "192.0.2.10:27015",
+					GMSIndex: -1,
+					SteamID:  90178913460052995,
+					AppId:    730,
+					GameDir:  "csgo",
+					Region:   -1,
+					Secure:   true,
+					GamePort: 27015,
+				}},
+			},
+		},
+		{
+			name:     "invalid address",
+			params:   GetServersAtAddressParams{Addr: "no-ip", Format: config.Vdf},
+			response: "\"response\"\n{\n\t\"success\"\t\"0\"\n\t\"message\"\t\"Invalid IP address\"\n}",
+			want:     &model.ServersAtAddress{Message: "Invalid IP address"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamApps/GetServersAtAddress/v1",
+				func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, tt.params.Addr, req.URL.Query().Get("addr"))
+					return httpmock.NewStringResponse(200, tt.response), nil
+				})
+
+			client := NewClientWithoutKey(&http.Client{})
+			got, err := client.GetServersAtAddress(tt.params)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUpToDateCheck(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	tests := []struct {
+		name     string
+		params   UpToDateCheckParams
+		response string
+		want     *model.UpToDateCheck
+		wantErr  bool
+	}{
+		{
+			name:     "out of date",
+			params:   UpToDateCheckParams{AppId: 440, Version: 100, Format: config.Json},
+			response: `{"response":{"success":true,"up_to_date":false,"version_is_listable":false,"required_version":8835751,"message":"Your server is out of date, please upgrade"}}`,
+			want: &model.UpToDateCheck{
+				Success:         true,
+				RequiredVersion: 8835751,
+				Message:         "Your server is out of date, please upgrade",
+			},
+		},
+		{
+			name:   "up to date",
+			params: UpToDateCheckParams{AppId: 440, Version: 8835751, Format: config.Xml},
+			response: `<?xml version="1.0" encoding="UTF-8"?>
+				<!DOCTYPE response>
+				<response>
+					<success>true</success>
+					<up_to_date>true</up_to_date>
+					<version_is_listable>true</version_is_listable>
+				</response>`,
+			want: &model.UpToDateCheck{Success: true, UpToDate: true, VersionIsListable: true},
+		},
+		{
+			name:    "unsupported format",
+			params:  UpToDateCheckParams{AppId: 440, Version: 1, Format: config.OutputFormat(42)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamApps/UpToDateCheck/v1",
+				func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "440", req.URL.Query().Get("appid"))
+					return httpmock.NewStringResponse(200, tt.response), nil
+				})
+
+			client := NewClientWithoutKey(&http.Client{})
+			got, err := client.UpToDateCheck(tt.params)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnsupportedFormat)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,9 +1,7 @@
 package steamclient
 
 import (
-	"errors"
-	"fmt"
-	"net/http"
+	"context"
 	"net/url"
 	"strconv"
 
@@ -40,6 +38,11 @@ Arguments:
 returns: output in the specified format
 */
 func (c Client) GetNewsForApp(params GetNewsForAppParams) (*model.AppNews, error) {
+	return c.GetNewsForAppCtx(context.Background(), params)
+}
+
+// GetNewsForAppCtx is like GetNewsForApp but sends the request with the given context.
+func (c Client) GetNewsForAppCtx(ctx context.Context, params GetNewsForAppParams) (*model.AppNews, error) {
 	version := "2"
 
 	vals := url.Values{}
@@ -53,16 +56,11 @@ func (c Client) GetNewsForApp(params GetNewsForAppParams) (*model.AppNews, error
 	}
 
 	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetNewsForAppEndpoint, Version: version}
-	url := urlHelper.RequestURLFormatter(ISteamNews, versUrlEndpoint, vals)
-
-	resp, err := c.getRequest(url)
+	resp, err := c.get(ctx, ISteamNews, versUrlEndpoint, vals)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("status code was " + fmt.Sprint(resp.StatusCode))
-	}
 	defer resp.Body.Close()
 
 	switch params.Format {
@@ -80,8 +78,15 @@ func (c Client) GetNewsForApp(params GetNewsForAppParams) (*model.AppNews, error
 		}
 		return &result, nil
 
+	case config.Vdf:
+		var result model.AppNewsResponse
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.AppNews, nil
+
 	default:
-		return nil, fmt.Errorf("unsupported format requested: %v", params.Format)
+		return nil, unsupportedFormatError(params.Format)
 	}
 }
 
@@ -1,9 +1,11 @@
 package steamclient
 
 import (
-	"errors"
+	"bytes"
+	"context"
 	"fmt"
-	"log"
+	"io"
+	"math"
 	"net/url"
 	"strconv"
 	"strings"
@@ -11,24 +13,40 @@ import (
 	urlHelper "github.com/xemkayx/steam-api/internal/urlHelper"
 	"github.com/xemkayx/steam-api/pkg/steamclient/config"
 	model "github.com/xemkayx/steam-api/pkg/steamclient/model/ISteamUser"
+	"github.com/xemkayx/steam-api/pkg/steamid"
 )
 
 const (
 	ISteamUser                 = "ISteamUser"
 	GetPlayerSummariesEndpoint = "GetPlayerSummaries" // v0002
 	GetFriendListEndpoint      = "GetFriendList"      // v0001
+	ResolveVanityURLEndpoint   = "ResolveVanityURL"   // v0001
+	GetPlayerBansEndpoint      = "GetPlayerBans"      // v0001
 )
 
 // Parameters for the GetFriendList method
 type GetFriendListParams struct {
-	SteamId      int64                          // SteamID of user
+	SteamId      steamid.ID                     // SteamID of user
 	Relationship config.FriendsListRelationship // 	relationship type
 	Format       config.OutputFormat            // Format of the output
 }
 
+// Parameters for the GetPlayerBans method
+type GetPlayerBansParams struct {
+	SteamIds []steamid.ID        // Comma-delimited list of SteamIDs (max: 100)
+	Format   config.OutputFormat // Format of the output
+}
+
+// Parameters for the ResolveVanityURL method
+type ResolveVanityURLParams struct {
+	VanityURL string               // The vanity URL part of the profile, e.g. robinwalker for steamcommunity.com/id/robinwalker
+	URLType   config.VanityURLType // Type of the vanity URL (default: config.Individual)
+	Format    config.OutputFormat  // Format of the output
+}
+
 // Parameters for the GetPlayerSummaries method
 type GetPlayerSummariesParams struct {
-	SteamIds []int64             // Comma-delimited list of SteamIDs (max: 100)
+	SteamIds []steamid.ID        // Comma-delimited list of SteamIDs (max: 100)
 	Format   config.OutputFormat // Format of the output
 }
 
@@ -44,18 +62,23 @@ Arguments
     Output format. json (default), xml or vdf.
 */
 func (c Client) GetPlayerSummaries(params GetPlayerSummariesParams) (*model.PlayerSummaries, error) {
+	return c.GetPlayerSummariesCtx(context.Background(), params)
+}
+
+// GetPlayerSummariesCtx is like GetPlayerSummaries but sends the request with the given context.
+func (c Client) GetPlayerSummariesCtx(ctx context.Context, params GetPlayerSummariesParams) (*model.PlayerSummaries, error) {
 	if !c.IsKeySet() {
-		return nil, errors.New(apiKeyErrorMessage)
+		return nil, ErrKeyRequired
 	}
 	version := "2"
 
-	if len(params.SteamIds) > 100 {
-		return nil, errors.New("you have provided too many Steam IDs. reduce the amount to 100")
+	if len(params.SteamIds) > MaxIDsPerRequest {
+		return nil, ErrTooManyIDs
 	}
 
 	strSlice := make([]string, len(params.SteamIds))
 	for i, id := range params.SteamIds {
-		strSlice[i] = strconv.FormatInt(id, 10)
+		strSlice[i] = id.String()
 	}
 
 	vals := url.Values{}
@@ -64,11 +87,8 @@ func (c Client) GetPlayerSummaries(params GetPlayerSummariesParams) (*model.Play
 	vals.Set("format", params.Format.String())
 
 	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetPlayerSummariesEndpoint, Version: version}
-	url := urlHelper.RequestURLFormatter(ISteamUser, versUrlEndpoint, vals)
-
-	resp, err := c.getRequest(url)
+	resp, err := c.get(ctx, ISteamUser, versUrlEndpoint, vals)
 	if err != nil {
-		log.Fatal(err)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -88,9 +108,37 @@ func (c Client) GetPlayerSummaries(params GetPlayerSummariesParams) (*model.Play
 		}
 		return &result, nil
 
+	case config.Vdf:
+		var result model.PlayerSummariesWrapper
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.PlayerSums, nil
+
 	default:
-		return nil, fmt.Errorf("unsupported format requested: %v", params.Format)
+		return nil, unsupportedFormatError(params.Format)
+	}
+}
+
+/*
+GetPlayerSummariesBatch is like GetPlayerSummaries, but accepts any number of SteamIDs.
+
+The IDs are de-duplicated and requested in chunks of 100, see BatchByID for the details.
+If some chunks fail, the summaries of the other chunks are returned together with a *BatchError.
+*/
+func (c Client) GetPlayerSummariesBatch(ctx context.Context, ids []steamid.ID, opts BatchOptions) ([]model.PlayerSummary, error) {
+	if !c.IsKeySet() {
+		return nil, ErrKeyRequired
+	}
+
+	fetch := func(ctx context.Context, chunk []steamid.ID) ([]model.PlayerSummary, error) {
+		result, err := c.GetPlayerSummariesCtx(ctx, GetPlayerSummariesParams{SteamIds: chunk, Format: opts.Format})
+		if err != nil {
+			return nil, err
+		}
+		return result.PlayerSums, nil
 	}
+	return BatchByID(ctx, ids, opts, fetch, func(p model.PlayerSummary) steamid.ID { return p.SteamID })
 }
 
 /*
@@ -107,22 +155,24 @@ Arguments
     Output format. json (default), xml or vdf.
 */
 func (c Client) GetFriendList(params GetFriendListParams) (*model.FriendList, error) {
+	return c.GetFriendListCtx(context.Background(), params)
+}
+
+// GetFriendListCtx is like GetFriendList but sends the request with the given context.
+func (c Client) GetFriendListCtx(ctx context.Context, params GetFriendListParams) (*model.FriendList, error) {
 	if !c.IsKeySet() {
-		return nil, errors.New(apiKeyErrorMessage)
+		return nil, ErrKeyRequired
 	}
 	version := "1"
 
 	vals := url.Values{}
 	vals.Set("key", c.Key)
-	vals.Set("steamid", strconv.FormatInt(params.SteamId, 10))
+	vals.Set("steamid", params.SteamId.String())
 	vals.Set("format", params.Format.String())
 
 	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetFriendListEndpoint, Version: version}
-	url := urlHelper.RequestURLFormatter(ISteamUser, versUrlEndpoint, vals)
-
-	resp, err := c.getRequest(url)
+	resp, err := c.get(ctx, ISteamUser, versUrlEndpoint, vals)
 	if err != nil {
-		log.Fatal(err)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -142,9 +192,290 @@ func (c Client) GetFriendList(params GetFriendListParams) (*model.FriendList, er
 		}
 		return &result, nil
 
+	case config.Vdf:
+		var result model.FriendListWrapper
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.FriendsList, nil
+
+	default:
+		return nil, unsupportedFormatError(params.Format)
+	}
+}
+
+/*
+Returns Community, VAC, game and economy ban statuses for a list of 64-bit Steam IDs.
+
+# Key required
+
+Arguments
+  - steamids
+    Comma-delimited list of 64 bit Steam IDs to return ban statuses for. Up to 100 Steam IDs can be requested.
+  - format
+    Output format. json (default), xml or vdf.
+*/
+func (c Client) GetPlayerBans(params GetPlayerBansParams) (*model.PlayerBans, error) {
+	return c.GetPlayerBansCtx(context.Background(), params)
+}
+
+// GetPlayerBansCtx is like GetPlayerBans but sends the request with the given context.
+func (c Client) GetPlayerBansCtx(ctx context.Context, params GetPlayerBansParams) (*model.PlayerBans, error) {
+	if !c.IsKeySet() {
+		return nil, ErrKeyRequired
+	}
+	version := "1"
+
+	if len(params.SteamIds) > MaxIDsPerRequest {
+		return nil, ErrTooManyIDs
+	}
+
+	strSlice := make([]string, len(params.SteamIds))
+	for i, id := range params.SteamIds {
+		strSlice[i] = id.String()
+	}
+
+	vals := url.Values{}
+	vals.Set("key", c.Key)
+	vals.Set("steamids", strings.Join(strSlice, ","))
+	vals.Set("format", params.Format.String())
+
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetPlayerBansEndpoint, Version: version}
+	resp, err := c.get(ctx, ISteamUser, versUrlEndpoint, vals)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// the players are not wrapped in a response object, so all formats decode into the same type
+	switch params.Format {
+	case config.Json:
+		var result model.PlayerBans
+		if _, err := decodeJSON(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result, nil
+
+	case config.Xml:
+		var result model.PlayerBans
+		if _, err := decodeXML(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result, nil
+
+	case config.Vdf:
+		var result model.PlayerBans
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result, nil
+
+	default:
+		return nil, unsupportedFormatError(params.Format)
+	}
+}
+
+/*
+GetPlayerBansBatch is like GetPlayerBans, but accepts any number of SteamIDs.
+
+The IDs are de-duplicated and requested in chunks of 100, see BatchByID for the details.
+If some chunks fail, the bans of the other chunks are returned together with a *BatchError.
+*/
+func (c Client) GetPlayerBansBatch(ctx context.Context, ids []steamid.ID, opts BatchOptions) ([]model.PlayerBan, error) {
+	if !c.IsKeySet() {
+		return nil, ErrKeyRequired
+	}
+
+	fetch := func(ctx context.Context, chunk []steamid.ID) ([]model.PlayerBan, error) {
+		result, err := c.GetPlayerBansCtx(ctx, GetPlayerBansParams{SteamIds: chunk, Format: opts.Format})
+		if err != nil {
+			return nil, err
+		}
+		return result.Players, nil
+	}
+	return BatchByID(ctx, ids, opts, fetch, func(b model.PlayerBan) steamid.ID { return b.SteamID })
+}
+
+/*
+Resolves a vanity URL to the 64 bit Steam ID it belongs to.
+
+If the vanity URL is not in use, an error wrapping ErrNoMatch is returned.
+
+# Key required
+
+Arguments
+  - vanityurl
+    The vanity URL to resolve, e.g. robinwalker for https://steamcommunity.com/id/robinwalker.
+  - url_type
+    The type of the vanity URL. 1 (default): individual profile, 2: group, 3: official game group.
+  - format
+    Output format. json (default), xml or vdf.
+*/
+func (c Client) ResolveVanityURL(params ResolveVanityURLParams) (*model.ResolvedVanityURL, error) {
+	return c.ResolveVanityURLCtx(context.Background(), params)
+}
+
+// ResolveVanityURLCtx is like ResolveVanityURL but sends the request with the given context.
+func (c Client) ResolveVanityURLCtx(ctx context.Context, params ResolveVanityURLParams) (*model.ResolvedVanityURL, error) {
+	if !c.IsKeySet() {
+		return nil, ErrKeyRequired
+	}
+	version := "1"
+
+	vals := url.Values{}
+	vals.Set("key", c.Key)
+	vals.Set("vanityurl", params.VanityURL)
+	vals.Set("url_type", params.URLType.String())
+	vals.Set("format", params.Format.String())
+
+	// a vanity URL without a match can be claimed any time, so only matches are cached
+	cacheable := func(body []byte) bool {
+		result, err := decodeResolvedVanityURL(params.Format, bytes.NewReader(body))
+		return err == nil && result.Success == model.VanityURLMatch
+	}
+
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: ResolveVanityURLEndpoint, Version: version}
+	resp, err := c.getCacheable(ctx, ISteamUser, versUrlEndpoint, vals, cacheable)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeResolvedVanityURL(params.Format, resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if result.Success != model.VanityURLMatch {
+		return nil, fmt.Errorf("%w: %q", ErrNoMatch, params.VanityURL)
+	}
+	return result, nil
+}
+
+// decodeResolvedVanityURL decodes a ResolveVanityURL response in the given format
+func decodeResolvedVanityURL(format config.OutputFormat, r io.Reader) (*model.ResolvedVanityURL, error) {
+	switch format {
+	case config.Json:
+		var wrapper model.ResolveVanityURLWrapper
+		if _, err := decodeJSON(&wrapper, r); err != nil {
+			return nil, err
+		}
+		return &wrapper.Response, nil
+
+	case config.Xml:
+		var resolved model.ResolvedVanityURL
+		if _, err := decodeXML(&resolved, r); err != nil {
+			return nil, err
+		}
+		return &resolved, nil
+
+	case config.Vdf:
+		var wrapper model.ResolveVanityURLWrapper
+		if _, err := decodeVDF(&wrapper, r); err != nil {
+			return nil, err
+		}
+		return &wrapper.Response, nil
+
 	default:
-		return nil, fmt.Errorf("unsupported format requested: %v", params.Format)
+		return nil, unsupportedFormatError(format)
+	}
+}
+
+/*
+ResolveProfile returns the SteamID of the profile the input points to. The input can be
+
+  - a profile URL, e.g. https://steamcommunity.com/id/robinwalker or steamcommunity.com/profiles/76561197960435530
+  - a SteamID64, e.g. 76561197960435530
+  - a Steam2 or Steam3 ID, e.g. STEAM_0:0:11101 or [U:1:22202]
+  - a vanity name, e.g. robinwalker
+
+Vanity names (also the ones in /id/ URLs) are resolved with ResolveVanityURL, so a key is required for them.
+An error wrapping ErrNoMatch is returned if no profile matches the input.
+*/
+func (c Client) ResolveProfile(input string) (steamid.ID, error) {
+	return c.ResolveProfileCtx(context.Background(), input)
+}
+
+// ResolveProfileCtx is like ResolveProfile but sends the request with the given context.
+func (c Client) ResolveProfileCtx(ctx context.Context, input string) (steamid.ID, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return 0, fmt.Errorf("%w: empty input", ErrNoMatch)
+	}
+
+	if isProfileURL(input) {
+		return c.resolveProfileURL(ctx, input)
+	}
+
+	if id, ok := parseProfileID(input); ok {
+		return id, nil
+	}
+	// Steam2 and Steam3 IDs can't be vanity names, so they are not looked up
+	if strings.HasPrefix(input, "STEAM_") || strings.HasPrefix(input, "[") {
+		return 0, fmt.Errorf("%w: %q is not a valid SteamID", ErrNoMatch, input)
+	}
+
+	return c.resolveVanityName(ctx, input)
+}
+
+// resolveProfileURL handles the /id/<vanity> and /profiles/<steamid> URLs of the Steam Community
+func (c Client) resolveProfileURL(ctx context.Context, input string) (steamid.ID, error) {
+	if !strings.Contains(input, "://") {
+		input = "https://" + input
+	}
+	u, err := url.Parse(input)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not a valid profile URL", ErrNoMatch, input)
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 2 || segments[1] == "" {
+		return 0, fmt.Errorf("%w: %q is not a profile URL", ErrNoMatch, input)
+	}
+
+	switch segments[0] {
+	case "id":
+		return c.resolveVanityName(ctx, segments[1])
+	case "profiles":
+		if id, ok := parseProfileID(segments[1]); ok {
+			return id, nil
+		}
+		return 0, fmt.Errorf("%w: %q does not contain a valid SteamID", ErrNoMatch, input)
+	default:
+		return 0, fmt.Errorf("%w: %q is not a profile URL", ErrNoMatch, input)
+	}
+}
+
+func (c Client) resolveVanityName(ctx context.Context, name string) (steamid.ID, error) {
+	resolved, err := c.ResolveVanityURLCtx(ctx, ResolveVanityURLParams{VanityURL: name, URLType: config.Individual})
+	if err != nil {
+		return 0, err
+	}
+	return resolved.SteamID, nil
+}
+
+// isProfileURL reports whether s is a link to the Steam Community, with or without scheme
+func isProfileURL(s string) bool {
+	lower := strings.ToLower(s)
+	lower = strings.TrimPrefix(lower, "https://")
+	lower = strings.TrimPrefix(lower, "http://")
+	lower = strings.TrimPrefix(lower, "www.")
+	return strings.HasPrefix(lower, "steamcommunity.com/")
+}
+
+/*
+parseProfileID parses SteamID64, Steam2 and Steam3 IDs.
+
+Unlike steamid.Parse it doesn't take small numbers as AccountIDs, since those are more likely to be vanity names.
+*/
+func parseProfileID(s string) (steamid.ID, bool) {
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil && n <= math.MaxUint32 {
+		return 0, false
+	}
+	id, err := steamid.Parse(s)
+	if err != nil {
+		return 0, false
 	}
+	return id, true
 }
 
 // TODO: other endpoints
@@ -4,15 +4,19 @@ package steamclient
 // ref: https://steamapi.xpaw.me/#ISteamUserStats
 
 import (
-	"errors"
+	"bytes"
+	"context"
 	"fmt"
-	"log"
+	"io"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 
 	urlHelper "github.com/xemkayx/steam-api/internal/urlHelper"
 	"github.com/xemkayx/steam-api/pkg/steamclient/config"
 	model "github.com/xemkayx/steam-api/pkg/steamclient/model/ISteamUserStats"
+	"github.com/xemkayx/steam-api/pkg/steamid"
 )
 
 const (
@@ -22,6 +26,7 @@ const (
 	GetGlobalAchievementPercentagesForAppEndpoint = "GetGlobalAchievementPercentagesForApp" // v0002
 	GetNumberOfCurrentPlayersEndpoint             = "GetNumberOfCurrentPlayers"             // v1
 	GetSchemaForGameEndpoint                      = "GetSchemaForGame"                      // v2
+	GetGlobalStatsForGameEndpoint                 = "GetGlobalStatsForGame"                 // v1
 	SetUserStatsForGameEndpoint                   = "SetUserStatsForGame"                   // v1
 )
 
@@ -49,7 +54,7 @@ type NumberOfCurrentPlayersParams struct {
 
 // Parameters for the GetPlayerAchievements method
 type PlayerAchievementsParams struct {
-	SteamId  uint64              // SteamID of user
+	SteamId  steamid.ID          // SteamID of user
 	AppId    uint32              // AppID to get achievements for
 	Format   config.OutputFormat // Format of the output
 	Language *config.Language    // (optional) output Language
@@ -62,9 +67,24 @@ type SchemaForGameParams struct {
 	Language *config.Language    // (optional) output Language
 }
 
+// A StatValue is the value a stat or achievement is set to
+type StatValue struct {
+	Name  string  // API name of the stat or achievement
+	Value float64 // Value of the stat; 1 unlocks an achievement, 0 locks it again
+}
+
+// Parameters for the SetUserStatsForGame method
+type SetUserStatsForGameParams struct {
+	SteamId steamid.ID            // SteamID of user
+	AppId   uint32                // appid of game
+	Stats   []StatValue           // Stats and achievements to set
+	Schema  *model.GameSchemaGame // (optional) schema of the game, to check the stats before sending them
+	Format  config.OutputFormat   // Format of the output
+}
+
 // Parameters for the GetUserStatsForGame method
 type UserStatsForGameParams struct {
-	SteamId  uint64              // SteamID of user
+	SteamId  steamid.ID          // SteamID of user
 	AppId    uint32              // appid of game
 	Format   config.OutputFormat // Format of the output
 	Language *config.Language    // (optional) output Language
@@ -82,6 +102,11 @@ Arguments
     Output format. json (default), xml or vdf.
 */
 func (c Client) GetGlobalAchievementPercentagesForApp(params GlobalAchievementPercentageParams) (*model.GlobalAchievementPercentages, error) {
+	return c.GetGlobalAchievementPercentagesForAppCtx(context.Background(), params)
+}
+
+// GetGlobalAchievementPercentagesForAppCtx is like GetGlobalAchievementPercentagesForApp but sends the request with the given context.
+func (c Client) GetGlobalAchievementPercentagesForAppCtx(ctx context.Context, params GlobalAchievementPercentageParams) (*model.GlobalAchievementPercentages, error) {
 	version := "2"
 
 	vals := url.Values{}
@@ -93,11 +118,8 @@ func (c Client) GetGlobalAchievementPercentagesForApp(params GlobalAchievementPe
 	}
 
 	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetGlobalAchievementPercentagesForAppEndpoint, Version: version}
-	url := urlHelper.RequestURLFormatter(ISteamUserStats, versUrlEndpoint, vals)
-
-	resp, err := c.getRequest(url)
+	resp, err := c.get(ctx, ISteamUserStats, versUrlEndpoint, vals)
 	if err != nil {
-		log.Fatal(err)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -117,67 +139,135 @@ func (c Client) GetGlobalAchievementPercentagesForApp(params GlobalAchievementPe
 		}
 		return &result, nil
 
+	case config.Vdf:
+		var result model.AchievementPercentagesResponse
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.GlobalAchievementPercentagesWrapper, nil
+
 	default:
-		return nil, fmt.Errorf("unsupported format requested: %v", params.Format)
+		return nil, unsupportedFormatError(params.Format)
+	}
+}
+
+/*
+GetGlobalStatsForGame returns the aggregated values of stats of a game over all players.
+If a start date is given, the daily totals in the date range are returned as well, oldest first.
+If Steam answers with a result other than 1, a *ResultError is returned.
+
+No key required.
+
+Arguments
+  - appid
+    AppID of the game.
+  - count
+    Number of stats to get data for. Defaults to the number of names.
+  - name[0]
+    Names of the stats to get data for. Only stats marked as aggregated in the game's schema are available.
+  - startdate
+    Start date for daily totals (unix epoch timestamp).
+  - enddate
+    End date for daily totals (unix epoch timestamp).
+  - format
+    Output format. json (default), xml or vdf.
+*/
+func (c Client) GetGlobalStatsForGame(params GlobalGameStatsParams) (*model.GlobalStatsForGame, error) {
+	return c.GetGlobalStatsForGameCtx(context.Background(), params)
+}
+
+// GetGlobalStatsForGameCtx is like GetGlobalStatsForGame but sends the request with the given context.
+func (c Client) GetGlobalStatsForGameCtx(ctx context.Context, params GlobalGameStatsParams) (*model.GlobalStatsForGame, error) {
+	version := "1"
+
+	count := params.Count
+	if count == 0 {
+		count = uint32(len(params.Names))
+	}
+
+	vals := url.Values{}
+	vals.Set("appid", strconv.FormatUint(uint64(params.AppID), 10))
+	vals.Set("count", strconv.FormatUint(uint64(count), 10))
+	vals.Set("format", params.Format.String())
+
+	for i, name := range params.Names {
+		vals.Set(fmt.Sprintf("name[%d]", i), name)
+	}
+
+	if params.StartDate != nil {
+		vals.Set("startdate", strconv.FormatUint(uint64(*params.StartDate), 10))
+	}
+	if params.EndDate != nil {
+		vals.Set("enddate", strconv.FormatUint(uint64(*params.EndDate), 10))
+	}
+
+	if c.IsKeySet() {
+		vals.Set("key", c.Key)
 	}
+
+	// failures like unknown stat names are answered with 200, they must not be cached
+	cacheable := func(body []byte) bool {
+		result, err := decodeGlobalStatsForGame(params.Format, bytes.NewReader(body))
+		return err == nil && result.Result == 1
+	}
+
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetGlobalStatsForGameEndpoint, Version: version}
+	resp, err := c.getCacheable(ctx, ISteamUserStats, versUrlEndpoint, vals, cacheable)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeGlobalStatsForGame(params.Format, resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Result != 1 {
+		return nil, &ResultError{Interface: ISteamUserStats, Endpoint: GetGlobalStatsForGameEndpoint, Result: result.Result, Message: result.Error}
+	}
+	for _, stat := range result.GlobalStats {
+		sort.Slice(stat.History, func(i, j int) bool { return stat.History[i].Date < stat.History[j].Date })
+	}
+	return result, nil
 }
 
-// todo: create return structure
-// func (c Client) GetGlobalStatsForGame(params GlobalGameStatsParams) (*model.GameStatsResponse, error) {
-// 	version := "1"
-// 	urlValues := url.Values{}
-// 	urlValues.Add("appid", strconv.FormatUint(uint64(params.AppID), 10))
-// 	urlValues.Add("count", strconv.FormatUint(uint64(params.Count), 10))
-// 	urlValues.Set("format", params.Format.String())
-
-// 	for _, name := range params.Names {
-// 		urlValues.Add("name", name)
-// 	}
-
-// 	if params.StartDate != nil {
-// 		urlValues.Add("startdate", strconv.FormatUint(uint64(*params.StartDate), 10))
-// 	}
-// 	if params.EndDate != nil {
-// 		urlValues.Add("enddate", strconv.FormatUint(uint64(*params.EndDate), 10))
-// 	}
-
-// 	versUrlEndpoint := format.VersionedURLEndpoint{EndpointPath: "GetGlobalStatsForGame", Version: version}
-// 	reqURL := urlHelper.RequestRequestURLFormatter(ISteamUserStats, versUrlEndpoint, urlValues)
-
-// 	resp, err := c.HttpClient.Get(reqURL)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	defer resp.Body.Close()
-
-// 	if resp.StatusCode != http.StatusOK {
-// 		return nil, fmt.Errorf("API request failed with status: %d", resp.StatusCode)
-// 	}
-
-// 	// TODO
-// 	// switch params.Format {
-// 	// case config.Json:
-// 	// 	var result model.AppNewsResponse
-// 	// 	if _, err := decodeJSON(&result, resp.Body); err != nil {
-// 	// 		return nil, err
-// 	// 	}
-// 	// 	return &result.AppNews, nil
-
-// 	// case config.Xml:
-// 	// 	var result model.AppNews
-// 	// 	if _, err := decodeXML(&result, resp.Body); err != nil {
-// 	// 		return nil, err
-// 	// 	}
-// 	// 	return &result, nil
-
-// 	// default:
-// 	// 	return nil, fmt.Errorf("unsupported format requested: %v", format)
-// 	// }
-// 	return nil, nil
-// }
+// decodeGlobalStatsForGame decodes a GetGlobalStatsForGame response in the given format
+func decodeGlobalStatsForGame(format config.OutputFormat, r io.Reader) (*model.GlobalStatsForGame, error) {
+	switch format {
+	case config.Json:
+		var wrapper model.GlobalStatsForGameWrapper
+		if _, err := decodeJSON(&wrapper, r); err != nil {
+			return nil, err
+		}
+		return &wrapper.Response, nil
+
+	case config.Xml:
+		var stats model.GlobalStatsForGame
+		if _, err := decodeXML(&stats, r); err != nil {
+			return nil, err
+		}
+		return &stats, nil
+
+	case config.Vdf:
+		var wrapper model.GlobalStatsForGameWrapper
+		if _, err := decodeVDF(&wrapper, r); err != nil {
+			return nil, err
+		}
+		return &wrapper.Response, nil
+
+	default:
+		return nil, unsupportedFormatError(format)
+	}
+}
 
 // TODO: model
 func (c Client) GetNumberOfCurrentPlayers(params NumberOfCurrentPlayersParams) (*model.NumberOfCurrentPlayers, error) {
+	return c.GetNumberOfCurrentPlayersCtx(context.Background(), params)
+}
+
+// GetNumberOfCurrentPlayersCtx is like GetNumberOfCurrentPlayers but sends the request with the given context.
+func (c Client) GetNumberOfCurrentPlayersCtx(ctx context.Context, params NumberOfCurrentPlayersParams) (*model.NumberOfCurrentPlayers, error) {
 	version := "1"
 
 	vals := url.Values{}
@@ -189,11 +279,8 @@ func (c Client) GetNumberOfCurrentPlayers(params NumberOfCurrentPlayersParams) (
 	}
 
 	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetNumberOfCurrentPlayersEndpoint, Version: version}
-	url := urlHelper.RequestURLFormatter(ISteamUserStats, versUrlEndpoint, vals)
-
-	resp, err := c.getRequest(url)
+	resp, err := c.get(ctx, ISteamUserStats, versUrlEndpoint, vals)
 	if err != nil {
-		log.Fatal(err)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -213,8 +300,15 @@ func (c Client) GetNumberOfCurrentPlayers(params NumberOfCurrentPlayersParams) (
 		}
 		return &result, nil
 
+	case config.Vdf:
+		var result model.NumberOfCurrentPlayersResponse
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.CurrentPlayers, nil
+
 	default:
-		return nil, fmt.Errorf("unsupported format requested: %v", params.Format)
+		return nil, unsupportedFormatError(params.Format)
 	}
 }
 
@@ -232,14 +326,19 @@ Arguments
     Language. If specified, it will return language data for the requested language.
 */
 func (c Client) GetPlayerAchievements(params PlayerAchievementsParams) (*model.PlayerAchievements, error) {
+	return c.GetPlayerAchievementsCtx(context.Background(), params)
+}
+
+// GetPlayerAchievementsCtx is like GetPlayerAchievements but sends the request with the given context.
+func (c Client) GetPlayerAchievementsCtx(ctx context.Context, params PlayerAchievementsParams) (*model.PlayerAchievements, error) {
 	if !c.IsKeySet() {
-		return nil, errors.New(apiKeyErrorMessage)
+		return nil, ErrKeyRequired
 	}
 	version := "1"
 
 	vals := url.Values{}
 	vals.Set("key", c.Key)
-	vals.Set("steamid", strconv.FormatUint(params.SteamId, 10))
+	vals.Set("steamid", params.SteamId.String())
 	vals.Set("appid", strconv.FormatInt(int64(params.AppId), 10))
 	vals.Set("format", params.Format.String())
 
@@ -248,11 +347,8 @@ func (c Client) GetPlayerAchievements(params PlayerAchievementsParams) (*model.P
 	}
 
 	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetPlayerAchievementsEndpoint, Version: version}
-	url := urlHelper.RequestURLFormatter(ISteamUserStats, versUrlEndpoint, vals)
-
-	resp, err := c.getRequest(url)
+	resp, err := c.get(ctx, ISteamUserStats, versUrlEndpoint, vals)
 	if err != nil {
-		log.Fatal(err)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -272,15 +368,27 @@ func (c Client) GetPlayerAchievements(params PlayerAchievementsParams) (*model.P
 		}
 		return &result, nil
 
+	case config.Vdf:
+		var result model.PlayerStatsWrapper
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.PlayerStats, nil
+
 	default:
-		return nil, fmt.Errorf("unsupported format requested: %v", params.Format)
+		return nil, unsupportedFormatError(params.Format)
 	}
 }
 
 // key required
 func (c Client) GetSchemaForGame(params SchemaForGameParams) (*model.GameSchemaGame, error) {
+	return c.GetSchemaForGameCtx(context.Background(), params)
+}
+
+// GetSchemaForGameCtx is like GetSchemaForGame but sends the request with the given context.
+func (c Client) GetSchemaForGameCtx(ctx context.Context, params SchemaForGameParams) (*model.GameSchemaGame, error) {
 	if !c.IsKeySet() {
-		return nil, errors.New(apiKeyErrorMessage)
+		return nil, ErrKeyRequired
 	}
 	version := "2"
 
@@ -294,11 +402,8 @@ func (c Client) GetSchemaForGame(params SchemaForGameParams) (*model.GameSchemaG
 	}
 
 	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetSchemaForGameEndpoint, Version: version}
-	url := urlHelper.RequestURLFormatter(ISteamUserStats, versUrlEndpoint, vals)
-
-	resp, err := c.getRequest(url)
+	resp, err := c.get(ctx, ISteamUserStats, versUrlEndpoint, vals)
 	if err != nil {
-		log.Fatal(err)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -318,8 +423,15 @@ func (c Client) GetSchemaForGame(params SchemaForGameParams) (*model.GameSchemaG
 		}
 		return &result, nil
 
+	case config.Vdf:
+		var result model.SchemaForGameResponse
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.SchemaForGameWrapper, nil
+
 	default:
-		return nil, fmt.Errorf("unsupported format requested: %v", params.Format)
+		return nil, unsupportedFormatError(params.Format)
 	}
 }
 
@@ -337,14 +449,19 @@ Arguments
     Language. If specified, it will return language data for the requested language.
 */
 func (c Client) GetUserStatsForGame(params UserStatsForGameParams) (*model.UserStats, error) {
+	return c.GetUserStatsForGameCtx(context.Background(), params)
+}
+
+// GetUserStatsForGameCtx is like GetUserStatsForGame but sends the request with the given context.
+func (c Client) GetUserStatsForGameCtx(ctx context.Context, params UserStatsForGameParams) (*model.UserStats, error) {
 	if !c.IsKeySet() {
-		return nil, errors.New(apiKeyErrorMessage)
+		return nil, ErrKeyRequired
 	}
 	version := "2"
 
 	vals := url.Values{}
 	vals.Set("key", c.Key)
-	vals.Set("steamid", strconv.FormatInt(int64(params.SteamId), 10))
+	vals.Set("steamid", params.SteamId.String())
 	vals.Set("appid", strconv.FormatInt(int64(params.AppId), 10))
 	vals.Set("format", params.Format.String())
 
@@ -353,11 +470,8 @@ func (c Client) GetUserStatsForGame(params UserStatsForGameParams) (*model.UserS
 	}
 
 	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetUserStatsForGameEndpoint, Version: version}
-	url := urlHelper.RequestURLFormatter(ISteamUserStats, versUrlEndpoint, vals)
-
-	resp, err := c.getRequest(url)
+	resp, err := c.get(ctx, ISteamUserStats, versUrlEndpoint, vals)
 	if err != nil {
-		log.Fatal(err)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -377,7 +491,156 @@ func (c Client) GetUserStatsForGame(params UserStatsForGameParams) (*model.UserS
 		}
 		return &result, nil
 
+	case config.Vdf:
+		var result model.UserStatsResponse
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.PlayerStats, nil
+
 	default:
-		return nil, fmt.Errorf("unsupported format requested: %v", params.Format)
+		return nil, unsupportedFormatError(params.Format)
+	}
+}
+
+/*
+SetUserStatsForGame sets stats and unlocks achievements of a player. It's a publisher method: the Client needs
+a publisher key (see WithPublisherKey) and the request is sent as a POST to the partner host. It is never retried.
+
+If a schema from GetSchemaForGame is passed, the names are checked against its stats and achievements and
+achievements only accept the values 0 and 1; invalid stats return an error wrapping ErrInvalidStat without
+sending anything.
+
+Steam answers with a single result for the whole call, not one per stat: either all stats are set or none.
+The Stats of the result list the passed stats with that same outcome in every entry, so Set is true for all of
+them or for none. If Steam rejected the stats, the result is returned together with a *ResultError.
+
+# Key required
+
+Arguments
+  - steamid
+    SteamID of the user.
+  - appid
+    AppID of the game.
+  - count
+    Number of stats and achievements to set, the number of Stats.
+  - name[0]
+    Name of the stat or achievement to set.
+  - value[0]
+    Value to set.
+  - format
+    Output format. json (default), xml or vdf.
+*/
+func (c Client) SetUserStatsForGame(params SetUserStatsForGameParams) (*model.SetUserStatsForGame, error) {
+	return c.SetUserStatsForGameCtx(context.Background(), params)
+}
+
+// SetUserStatsForGameCtx is like SetUserStatsForGame but sends the request with the given context.
+func (c Client) SetUserStatsForGameCtx(ctx context.Context, params SetUserStatsForGameParams) (*model.SetUserStatsForGame, error) {
+	if !c.IsKeySet() {
+		return nil, ErrKeyRequired
+	}
+	if !c.PublisherKey {
+		return nil, ErrPublisherKey
+	}
+	achievements, err := validateStats(params.Schema, params.Stats)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(params.Stats))
+	values := make([]string, len(params.Stats))
+	for i, stat := range params.Stats {
+		names[i] = stat.Name
+		values[i] = strconv.FormatFloat(stat.Value, 'f', -1, 64)
+	}
+
+	req := NewRequest(ISteamUserStats, SetUserStatsForGameEndpoint, 1).Post().
+		Format(params.Format).
+		Set("steamid", params.SteamId.String()).
+		Set("appid", strconv.FormatUint(uint64(params.AppId), 10)).
+		Set("count", strconv.Itoa(len(params.Stats))).
+		SetList("name", names).
+		SetList("value", values)
+	resp, err := c.Send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result *model.SetUserStatsForGame
+	switch params.Format {
+	case config.Json:
+		var wrapper model.SetUserStatsForGameWrapper
+		if _, err := decodeJSON(&wrapper, resp.Body); err != nil {
+			return nil, err
+		}
+		result = &wrapper.Response
+
+	case config.Xml:
+		var set model.SetUserStatsForGame
+		if _, err := decodeXML(&set, resp.Body); err != nil {
+			return nil, err
+		}
+		result = &set
+
+	case config.Vdf:
+		var wrapper model.SetUserStatsForGameWrapper
+		if _, err := decodeVDF(&wrapper, resp.Body); err != nil {
+			return nil, err
+		}
+		result = &wrapper.Response
+
+	default:
+		return nil, unsupportedFormatError(params.Format)
+	}
+
+	result.Stats = make([]model.StatResult, len(params.Stats))
+	for i, stat := range params.Stats {
+		result.Stats[i] = model.StatResult{
+			Name:        stat.Name,
+			Value:       stat.Value,
+			Achievement: achievements[stat.Name],
+			Set:         result.Result == 1,
+		}
+	}
+
+	if result.Result != 1 {
+		return result, &ResultError{Interface: ISteamUserStats, Endpoint: SetUserStatsForGameEndpoint, Result: result.Result, Message: result.Error}
+	}
+	return result, nil
+}
+
+// validateStats checks stats against the schema, if there is one, and returns the names of the achievements among them
+func validateStats(schema *model.GameSchemaGame, stats []StatValue) (map[string]bool, error) {
+	if len(stats) == 0 {
+		return nil, fmt.Errorf("%w: no stats to set", ErrInvalidStat)
+	}
+	if schema == nil {
+		return nil, nil
+	}
+
+	known := map[string]bool{}
+	for _, stat := range schema.AvailableGameStats.Stats {
+		known[stat.Name] = true
+	}
+	achievements := map[string]bool{}
+	for _, achievement := range schema.AvailableGameStats.Achievements {
+		achievements[achievement.Name] = true
+	}
+
+	var problems []string
+	for _, stat := range stats {
+		switch {
+		case achievements[stat.Name]:
+			if stat.Value != 0 && stat.Value != 1 {
+				problems = append(problems, fmt.Sprintf("achievement %s can only be set to 0 or 1, not %v", stat.Name, stat.Value))
+			}
+		case !known[stat.Name]:
+			problems = append(problems, fmt.Sprintf("%s is not in the schema of %s", stat.Name, schema.GameName))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidStat, strings.Join(problems, "; "))
 	}
+	return achievements, nil
 }
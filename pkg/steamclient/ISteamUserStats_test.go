@@ -44,6 +44,7 @@ func TestGetGlobalAchievementPercentagesForApp(t *testing.T) {
 				fmt.Fprintln(w, tt.mockResponse)
 			}))
 			defer server.Close()
+			client.BaseURL = server.URL
 			vals := url.Values{}
 			vals.Set("gameid", strconv.FormatUint(tt.params.GameId, 10))
 			vals.Set("format", tt.params.Format.String())
@@ -157,7 +158,7 @@ func TestGetPlayerAchievements(t *testing.T) {
 	  			}
 	  		}`,
 			want: &model.PlayerAchievements{
-				SteamID:  "123456789",
+				SteamID:  123456789,
 				GameName: "Counter-Strike 2",
 				Achievements: []model.Achievement{
 					{
@@ -352,7 +353,7 @@ func TestGetUserStatsForGame(t *testing.T) {
                 }
             }`,
 			want: &model.UserStats{
-				SteamID:  "123456789",
+				SteamID:  123456789,
 				GameName: "ValveTestApp260",
 				Stats: []model.Stat{
 					{
@@ -400,3 +401,291 @@ func TestGetUserStatsForGame(t *testing.T) {
 		})
 	}
 }
+
+func TestGetGlobalStatsForGame(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	startDate := uint32(1360454400)
+	endDate := uint32(1360627200)
+
+	withHistory := &model.GlobalStatsForGame{
+		GlobalStats: model.GlobalStatMap{
+			"global.map.emp_isle": {
+				Total: 4292527,
+				History: model.History{
+					{Date: 1360454400, Total: 26211},
+					{Date: 1360540800, Total: 27306},
+				},
+			},
+			"global.map.emp_district": {Total: 13105},
+		},
+		Result: 1,
+	}
+
+	tests := []struct {
+		name     string
+		params   GlobalGameStatsParams
+		response string
+		want     *model.GlobalStatsForGame
+		wantErr  bool
+	}{
+		{
+			name: "JSON",
+			params: GlobalGameStatsParams{
+				AppID:     17740,
+				Names:     []string{"global.map.emp_isle", "global.map.emp_district"},
+				StartDate: &startDate,
+				EndDate:   &endDate,
+				Format:    config.Json,
+			},
+			response: `{"response":{"globalstats":{
+				"global.map.emp_isle":{"total":"4292527","history":[{"date":1360540800,"total":"27306"},{"date":1360454400,"total":"26211"}]},
+				"global.map.emp_district":{"total":"13105"}},"result":1}}`,
+			want: withHistory,
+		},
+		{
+			name: "XML",
+			params: GlobalGameStatsParams{
+				AppID:     17740,
+				Names:     []string{"global.map.emp_isle", "global.map.emp_district"},
+				StartDate: &startDate,
+				EndDate:   &endDate,
+				Format:    config.Xml,
+			},
+			response: `<?xml version="1.0" encoding="UTF-8"?>
+				<!DOCTYPE response>
+				<response>
+					<globalstats>
+						<global.map.emp_isle>
+							<total>4292527</total>
+							<history>
+								<day><date>1360540800</date><total>27306</total></day>
+								<day><date>1360454400</date><total>26211</total></day>
+							</history>
+						</global.map.emp_isle>
+						<global.map.emp_district>
+							<total>13105</total>
+						</global.map.emp_district>
+					</globalstats>
+					<result>1</result>
+				</response>`,
+			want: withHistory,
+		},
+		{
+			name: "VDF",
+			params: GlobalGameStatsParams{
+				AppID:  17740,
+				Names:  []string{"global.map.emp_isle", "global.map.emp_district"},
+				Format: config.Vdf,
+			},
+			response: `"response"
+				{
+					"globalstats"
+					{
+						"global.map.emp_isle"
+						{
+							"total"	"4292527"
+						}
+						"global.map.emp_district"
+						{
+							"total"	"13105"
+						}
+					}
+					"result"	"1"
+				}`,
+			want: &model.GlobalStatsForGame{
+				GlobalStats: model.GlobalStatMap{
+					"global.map.emp_isle":     {Total: 4292527},
+					"global.map.emp_district": {Total: 13105},
+				},
+				Result: 1,
+			},
+		},
+		{
+			name: "failed",
+			params: GlobalGameStatsParams{
+				AppID:  17740,
+				Names:  []string{"global.map.emp_isle", "global.map.emp_district"},
+				Format: config.Json,
+			},
+			response: `{"response":{"result":8,"error":"Missing or invalid required parameter: name[0]"}}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamUserStats/GetGlobalStatsForGame/v1",
+				func(req *http.Request) (*http.Response, error) {
+					query := req.URL.Query()
+					assert.Equal(t, "17740", query.Get("appid"))
+					assert.Equal(t, "2", query.Get("count"), "count defaults to the number of names")
+					assert.Equal(t, "global.map.emp_isle", query.Get("name[0]"))
+					assert.Equal(t, "global.map.emp_district", query.Get("name[1]"))
+					if tt.params.StartDate != nil {
+						assert.Equal(t, "1360454400", query.Get("startdate"))
+						assert.Equal(t, "1360627200", query.Get("enddate"))
+					}
+					return httpmock.NewStringResponse(200, tt.response), nil
+				})
+
+			client := NewClientWithoutKey(&http.Client{})
+			got, err := client.GetGlobalStatsForGame(tt.params)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrResultFailed)
+				var resultErr *ResultError
+				if assert.ErrorAs(t, err, &resultErr) {
+					assert.Equal(t, 8, resultErr.Result)
+					assert.Equal(t, "Missing or invalid required parameter: name[0]", resultErr.Message)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetUserStatsForGame(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	schema := &model.GameSchemaGame{
+		GameName: "Spacewar",
+		AvailableGameStats: model.GameSchemaAvailableGameStats{
+			Stats:        []model.GameSchemaStat{{Name: "NumGames"}, {Name: "MaxFeetTraveled"}},
+			Achievements: []model.GameSchemaAchievement{{Name: "ACH_WIN_ONE_GAME"}},
+		},
+	}
+	stats := []StatValue{
+		{Name: "NumGames", Value: 10},
+		{Name: "MaxFeetTraveled", Value: 12.5},
+		{Name: "ACH_WIN_ONE_GAME", Value: 1},
+	}
+
+	tests := []struct {
+		name      string
+		client    *Client
+		params    SetUserStatsForGameParams
+		response  string
+		status    int
+		want      []model.StatResult
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:     "success",
+			client:   NewWithOptions(WithPublisherKey("publisher-key")),
+			params:   SetUserStatsForGameParams{SteamId: 76561197960435530, AppId: 480, Stats: stats, Schema: schema, Format: config.Json},
+			response: `{"response":{"result":1}}`,
+			want: []model.StatResult{
+				{Name: "NumGames", Value: 10, Set: true},
+				{Name: "MaxFeetTraveled", Value: 12.5, Set: true},
+				{Name: "ACH_WIN_ONE_GAME", Value: 1, Achievement: true, Set: true},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "rejected",
+			client: NewWithOptions(WithPublisherKey("publisher-key")),
+			params: SetUserStatsForGameParams{SteamId: 76561197960435530, AppId: 480, Stats: stats, Format: config.Vdf},
+			response: `"response"
+				{
+					"result"	"8"
+					"error"	"Invalid stat"
+				}`,
+			want: []model.StatResult{
+				{Name: "NumGames", Value: 10},
+				{Name: "MaxFeetTraveled", Value: 12.5},
+				{Name: "ACH_WIN_ONE_GAME", Value: 1},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:      "server error is not retried",
+			client:    NewWithOptions(WithPublisherKey("publisher-key"), WithRetry(&RetryPolicy{MaxAttempts: 3})),
+			params:    SetUserStatsForGameParams{SteamId: 76561197960435530, AppId: 480, Stats: stats, Format: config.Json},
+			status:    503,
+			wantCalls: 1,
+		},
+		{
+			name:    "unknown stat",
+			client:  NewWithOptions(WithPublisherKey("publisher-key")),
+			params:  SetUserStatsForGameParams{AppId: 480, Stats: []StatValue{{Name: "NumLosses", Value: 1}}, Schema: schema},
+			wantErr: ErrInvalidStat,
+		},
+		{
+			name:    "invalid achievement value",
+			client:  NewWithOptions(WithPublisherKey("publisher-key")),
+			params:  SetUserStatsForGameParams{AppId: 480, Stats: []StatValue{{Name: "ACH_WIN_ONE_GAME", Value: 2}}, Schema: schema},
+			wantErr: ErrInvalidStat,
+		},
+		{
+			name:    "no stats",
+			client:  NewWithOptions(WithPublisherKey("publisher-key")),
+			params:  SetUserStatsForGameParams{AppId: 480},
+			wantErr: ErrInvalidStat,
+		},
+		{
+			name:    "no publisher key",
+			client:  New("test-key", &http.Client{}),
+			params:  SetUserStatsForGameParams{AppId: 480, Stats: stats},
+			wantErr: ErrPublisherKey,
+		},
+		{
+			name:    "no key",
+			client:  NewClientWithoutKey(&http.Client{}),
+			params:  SetUserStatsForGameParams{AppId: 480, Stats: stats},
+			wantErr: ErrKeyRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+			httpmock.RegisterResponder("POST", "https://partner.steam-api.com/ISteamUserStats/SetUserStatsForGame/v1",
+				func(req *http.Request) (*http.Response, error) {
+					assert.NoError(t, req.ParseForm())
+					assert.Equal(t, url.Values{
+						"key":      {"publisher-key"},
+						"steamid":  {"76561197960435530"},
+						"appid":    {"480"},
+						"count":    {"3"},
+						"format":   {tt.params.Format.String()},
+						"name[0]":  {"NumGames"},
+						"value[0]": {"10"},
+						"name[1]":  {"MaxFeetTraveled"},
+						"value[1]": {"12.5"},
+						"name[2]":  {"ACH_WIN_ONE_GAME"},
+						"value[2]": {"1"},
+					}, req.PostForm)
+					status := tt.status
+					if status == 0 {
+						status = 200
+					}
+					return httpmock.NewStringResponse(status, tt.response), nil
+				})
+
+			got, err := tt.client.SetUserStatsForGame(tt.params)
+			assert.Equal(t, tt.wantCalls, httpmock.GetTotalCallCount())
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.status != 0:
+				assert.ErrorAs(t, err, new(*APIError))
+			case tt.want[0].Set:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got.Stats)
+			default:
+				assert.ErrorIs(t, err, ErrResultFailed)
+				var resultErr *ResultError
+				if assert.ErrorAs(t, err, &resultErr) {
+					assert.Equal(t, 8, resultErr.Result)
+					assert.Equal(t, "Invalid stat", resultErr.Message)
+				}
+				assert.Equal(t, tt.want, got.Stats)
+			}
+		})
+	}
+}
@@ -4,12 +4,12 @@ import (
 	"errors"
 	"net/http"
 	"reflect"
-	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/xemkayx/steam-api/pkg/steamclient/config"
 	model "github.com/xemkayx/steam-api/pkg/steamclient/model/ISteamUser"
+	"github.com/xemkayx/steam-api/pkg/steamid"
 
 	"github.com/jarcoal/httpmock"
 )
@@ -37,7 +37,7 @@ func TestGetPlayerSummaries(t *testing.T) {
 			client: New("test-key", httpClient),
 			name:   "TestGetPlayerSummaries 1 - XML",
 			params: GetPlayerSummariesParams{
-				SteamIds: []int64{76561197960435530},
+				SteamIds: []steamid.ID{76561197960435530},
 				Format:   config.Xml,
 			},
 			response: `<?xml version="1.0" encoding="UTF-8"?>
@@ -68,7 +68,7 @@ func TestGetPlayerSummaries(t *testing.T) {
 			want: &model.PlayerSummaries{
 				PlayerSums: []model.PlayerSummary{
 					{
-						SteamID:                  "76561197960435530",
+						SteamID:                  76561197960435530,
 						CommunityVisibilityState: 3,
 						ProfileState:             1,
 						PersonaName:              "Robin",
@@ -94,14 +94,72 @@ func TestGetPlayerSummaries(t *testing.T) {
 			client: New("test-key", httpClient),
 			name:   "TestGetPlayerSummaries 2 - JSON",
 			params: GetPlayerSummariesParams{
-				SteamIds: []int64{76561197960435530},
+				SteamIds: []steamid.ID{76561197960435530},
 				Format:   config.Json,
 			},
 			response: `{"response":{"players":[{"steamid":"76561197960435530","communityvisibilitystate":3,"profilestate":1,"personaname":"Robin","profileurl":"https://steamcommunity.com/id/robinwalker/","avatar":"https://avatars.steamstatic.com/81b5478529dce13bf24b55ac42c1af7058aaf7a9.jpg","avatarmedium":"https://avatars.steamstatic.com/81b5478529dce13bf24b55ac42c1af7058aaf7a9_medium.jpg","avatarfull":"https://avatars.steamstatic.com/81b5478529dce13bf24b55ac42c1af7058aaf7a9_full.jpg","avatarhash":"81b5478529dce13bf24b55ac42c1af7058aaf7a9","personastate":0,"realname":"Robin Walker","primaryclanid":"103582791429521412","timecreated":1063407589,"personastateflags":0,"loccountrycode":"US","locstatecode":"WA","loccityid":3961}]}}`,
 			want: &model.PlayerSummaries{
 				PlayerSums: []model.PlayerSummary{
 					{
-						SteamID:                  "76561197960435530",
+						SteamID:                  76561197960435530,
+						CommunityVisibilityState: 3,
+						ProfileState:             1,
+						PersonaName:              "Robin",
+						ProfileURL:               "https://steamcommunity.com/id/robinwalker/",
+						Avatar:                   "https://avatars.steamstatic.com/81b5478529dce13bf24b55ac42c1af7058aaf7a9.jpg",
+						AvatarMedium:             "https://avatars.steamstatic.com/81b5478529dce13bf24b55ac42c1af7058aaf7a9_medium.jpg",
+						AvatarFull:               "https://avatars.steamstatic.com/81b5478529dce13bf24b55ac42c1af7058aaf7a9_full.jpg",
+						AvatarHash:               "81b5478529dce13bf24b55ac42c1af7058aaf7a9",
+						PersonaState:             0,
+						RealName:                 "Robin Walker",
+						PrimaryClanID:            "103582791429521412",
+						TimeCreated:              1063407589,
+						PersonaStateFlags:        0,
+						LocCountryCode:           &locCountryCode,
+						LocStateCode:             &locStateCode,
+						LocCityId:                &locCityId,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			client: New("test-key", httpClient),
+			name:   "TestGetPlayerSummaries 3 - VDF",
+			params: GetPlayerSummariesParams{
+				SteamIds: []steamid.ID{76561197960435530},
+				Format:   config.Vdf,
+			},
+			response: `"response"
+			{
+				"players"
+				{
+					"0"
+					{
+						"steamid"		"76561197960435530"
+						"communityvisibilitystate"		"3"
+						"profilestate"		"1"
+						"personaname"		"Robin"
+						"profileurl"		"https://steamcommunity.com/id/robinwalker/"
+						"avatar"		"https://avatars.steamstatic.com/81b5478529dce13bf24b55ac42c1af7058aaf7a9.jpg"
+						"avatarmedium"		"https://avatars.steamstatic.com/81b5478529dce13bf24b55ac42c1af7058aaf7a9_medium.jpg"
+						"avatarfull"		"https://avatars.steamstatic.com/81b5478529dce13bf24b55ac42c1af7058aaf7a9_full.jpg"
+						"avatarhash"		"81b5478529dce13bf24b55ac42c1af7058aaf7a9"
+						"personastate"		"0"
+						"realname"		"Robin Walker"
+						"primaryclanid"		"103582791429521412"
+						"timecreated"		"1063407589"
+						"personastateflags"		"0"
+						"loccountrycode"		"US"
+						"locstatecode"		"WA"
+						"loccityid"		"3961"
+					}
+				}
+			}`,
+			want: &model.PlayerSummaries{
+				PlayerSums: []model.PlayerSummary{
+					{
+						SteamID:                  76561197960435530,
 						CommunityVisibilityState: 3,
 						ProfileState:             1,
 						PersonaName:              "Robin",
@@ -131,7 +189,7 @@ func TestGetPlayerSummaries(t *testing.T) {
 				func(req *http.Request) (*http.Response, error) {
 					steamIds := make([]string, len(tc.params.SteamIds))
 					for i, v := range tc.params.SteamIds {
-						steamIds[i] = strconv.FormatInt(v, 10)
+						steamIds[i] = v.String()
 					}
 
 					if req.URL.Query().Get("key") != tc.client.Key ||
@@ -173,10 +231,10 @@ func TestGetPlayerSummariesErrors(t *testing.T) {
 		wantErr  bool
 	}
 
-	var sIds []int64
+	var sIds []steamid.ID
 
 	for i := range 150 {
-		sIds = append(sIds, int64(i))
+		sIds = append(sIds, steamid.ID(i))
 	}
 
 	testCases := []testCase{
@@ -264,12 +322,12 @@ func TestGetFriendList(t *testing.T) {
 			want: &model.FriendList{
 				Friends: []model.Friend{
 					{
-						SteamId:      "76561197960265731",
+						SteamId:      76561197960265731,
 						Relationship: "friend",
 						FriendSince:  0,
 					},
 					{
-						SteamId:      "76561199147650161",
+						SteamId:      76561199147650161,
 						Relationship: "friend",
 						FriendSince:  1614836906,
 					},
@@ -302,12 +360,52 @@ func TestGetFriendList(t *testing.T) {
 			want: &model.FriendList{
 				Friends: []model.Friend{
 					{
-						SteamId:      "76561197960265731",
+						SteamId:      76561197960265731,
+						Relationship: "friend",
+						FriendSince:  0,
+					},
+					{
+						SteamId:      76561199147650161,
+						Relationship: "friend",
+						FriendSince:  1614836906,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "TestGetFriendList 3 - VDF Success",
+			params: GetFriendListParams{
+				SteamId: 12345,
+				Format:  config.Vdf,
+			},
+			response: `"friendslist"
+			{
+				"friends"
+				{
+					"0"
+					{
+						"steamid"		"76561197960265731"
+						"relationship"		"friend"
+						"friend_since"		"0"
+					}
+					"1"
+					{
+						"steamid"		"76561199147650161"
+						"relationship"		"friend"
+						"friend_since"		"1614836906"
+					}
+				}
+			}`,
+			want: &model.FriendList{
+				Friends: []model.Friend{
+					{
+						SteamId:      76561197960265731,
 						Relationship: "friend",
 						FriendSince:  0,
 					},
 					{
-						SteamId:      "76561199147650161",
+						SteamId:      76561199147650161,
 						Relationship: "friend",
 						FriendSince:  1614836906,
 					},
@@ -322,7 +420,7 @@ func TestGetFriendList(t *testing.T) {
 			httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamUser/GetFriendList/v1",
 				func(req *http.Request) (*http.Response, error) {
 					if req.URL.Query().Get("key") != "test-key" ||
-						req.URL.Query().Get("steamid") != strconv.FormatInt(tc.params.SteamId, 10) ||
+						req.URL.Query().Get("steamid") != tc.params.SteamId.String() ||
 						req.URL.Query().Get("format") != tc.params.Format.String() {
 						t.Errorf("Request parameters do not match")
 					}
@@ -391,3 +489,342 @@ func TestGetFriendListErrors(t *testing.T) {
 
 	}
 }
+
+func TestResolveVanityURL(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	type testCase struct {
+		name     string
+		params   ResolveVanityURLParams
+		response string
+		want     *model.ResolvedVanityURL
+		wantErr  error
+	}
+
+	testCases := []testCase{
+		{
+			name:     "TestResolveVanityURL 1 - JSON",
+			params:   ResolveVanityURLParams{VanityURL: "robinwalker", Format: config.Json},
+			response: `{"response":{"steamid":"76561197960435530","success":1}}`,
+			want:     &model.ResolvedVanityURL{SteamID: 76561197960435530, Success: model.VanityURLMatch},
+		},
+		{
+			name:   "TestResolveVanityURL 2 - XML",
+			params: ResolveVanityURLParams{VanityURL: "robinwalker", Format: config.Xml},
+			response: `<?xml version="1.0" encoding="UTF-8"?>
+			<!DOCTYPE response>
+			<response>
+				<steamid>76561197960435530</steamid>
+				<success>1</success>
+			</response>`,
+			want: &model.ResolvedVanityURL{SteamID: 76561197960435530, Success: model.VanityURLMatch},
+		},
+		{
+			name:   "TestResolveVanityURL 3 - VDF",
+			params: ResolveVanityURLParams{VanityURL: "robinwalker", Format: config.Vdf},
+			response: `"response"
+			{
+				"steamid"	"76561197960435530"
+				"success"	"1"
+			}`,
+			want: &model.ResolvedVanityURL{SteamID: 76561197960435530, Success: model.VanityURLMatch},
+		},
+		{
+			name:     "TestResolveVanityURL 4 - Group",
+			params:   ResolveVanityURLParams{VanityURL: "valve", URLType: config.Group, Format: config.Json},
+			response: `{"response":{"steamid":"103582791429521412","success":1}}`,
+			want:     &model.ResolvedVanityURL{SteamID: 103582791429521412, Success: model.VanityURLMatch},
+		},
+		{
+			name:     "TestResolveVanityURL 5 - No Match",
+			params:   ResolveVanityURLParams{VanityURL: "doesnotexist", Format: config.Json},
+			response: `{"response":{"success":42,"message":"No match"}}`,
+			wantErr:  ErrNoMatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamUser/ResolveVanityURL/v1",
+				func(req *http.Request) (*http.Response, error) {
+					if req.URL.Query().Get("key") != "test-key" ||
+						req.URL.Query().Get("vanityurl") != tc.params.VanityURL ||
+						req.URL.Query().Get("url_type") != tc.params.URLType.String() ||
+						req.URL.Query().Get("format") != tc.params.Format.String() {
+						t.Errorf("Request parameters do not match")
+					}
+					return httpmock.NewStringResponse(200, tc.response), nil
+				})
+
+			api := New("test-key", &http.Client{})
+			got, err := api.ResolveVanityURL(tc.params)
+
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("ResolveVanityURL() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ResolveVanityURL() got = %v , expected %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResolveProfile(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamUser/ResolveVanityURL/v1",
+		func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("url_type") != "1" {
+				t.Errorf("Vanity names should be resolved as individual profiles")
+			}
+			if req.URL.Query().Get("vanityurl") == "robinwalker" {
+				return httpmock.NewStringResponse(200, `{"response":{"steamid":"76561197960435530","success":1}}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"response":{"success":42,"message":"No match"}}`), nil
+		})
+
+	testCases := []struct {
+		input   string
+		want    steamid.ID
+		wantErr error
+	}{
+		{input: "robinwalker", want: 76561197960435530},
+		{input: "https://steamcommunity.com/id/robinwalker/", want: 76561197960435530},
+		{input: "steamcommunity.com/id/robinwalker", want: 76561197960435530},
+		{input: "https://steamcommunity.com/profiles/76561197960435530", want: 76561197960435530},
+		{input: "http://www.steamcommunity.com/profiles/[U:1:169802]/games", want: 76561197960435530},
+		{input: " 76561197960435530 ", want: 76561197960435530},
+		{input: "STEAM_0:0:84901", want: 76561197960435530},
+		{input: "[U:1:169802]", want: 76561197960435530},
+		{input: "12345", wantErr: ErrNoMatch},
+		{input: "doesnotexist", wantErr: ErrNoMatch},
+		{input: "STEAM_9:0:11101", wantErr: ErrNoMatch},
+		{input: "https://steamcommunity.com/groups/valve", wantErr: ErrNoMatch},
+		{input: "https://steamcommunity.com/profiles/notanid", wantErr: ErrNoMatch},
+		{input: "", wantErr: ErrNoMatch},
+	}
+
+	api := New("test-key", &http.Client{})
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := api.ResolveProfile(tc.input)
+
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("ResolveProfile() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ResolveProfile() got = %v , expected %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetPlayerBans(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	type testCase struct {
+		name     string
+		params   GetPlayerBansParams
+		response string
+		want     *model.PlayerBans
+		wantErr  bool
+	}
+
+	want := &model.PlayerBans{
+		Players: []model.PlayerBan{
+			{
+				SteamID:          76561197960435530,
+				CommunityBanned:  false,
+				VACBanned:        false,
+				NumberOfVACBans:  0,
+				DaysSinceLastBan: 0,
+				NumberOfGameBans: 0,
+				EconomyBan:       model.EconomyBanNone,
+			},
+			{
+				SteamID:          76561197960287930,
+				CommunityBanned:  true,
+				VACBanned:        true,
+				NumberOfVACBans:  2,
+				DaysSinceLastBan: 731,
+				NumberOfGameBans: 1,
+				EconomyBan:       model.EconomyBanProbation,
+			},
+		},
+	}
+
+	testCases := []testCase{
+		{
+			name: "TestGetPlayerBans 1 - JSON",
+			params: GetPlayerBansParams{
+				SteamIds: []steamid.ID{76561197960435530, 76561197960287930},
+				Format:   config.Json,
+			},
+			response: `{
+				"players": [
+					{
+						"SteamId": "76561197960435530",
+						"CommunityBanned": false,
+						"VACBanned": false,
+						"NumberOfVACBans": 0,
+						"DaysSinceLastBan": 0,
+						"NumberOfGameBans": 0,
+						"EconomyBan": "none"
+					},
+					{
+						"SteamId": "76561197960287930",
+						"CommunityBanned": true,
+						"VACBanned": true,
+						"NumberOfVACBans": 2,
+						"DaysSinceLastBan": 731,
+						"NumberOfGameBans": 1,
+						"EconomyBan": "probation"
+					}
+				]
+			}`,
+			want:    want,
+			wantErr: false,
+		},
+		{
+			name: "TestGetPlayerBans 2 - XML",
+			params: GetPlayerBansParams{
+				SteamIds: []steamid.ID{76561197960435530, 76561197960287930},
+				Format:   config.Xml,
+			},
+			response: `<?xml version="1.0" encoding="UTF-8"?>
+			<!DOCTYPE response>
+			<response>
+				<players>
+					<player>
+						<SteamId>76561197960435530</SteamId>
+						<CommunityBanned>false</CommunityBanned>
+						<VACBanned>false</VACBanned>
+						<NumberOfVACBans>0</NumberOfVACBans>
+						<DaysSinceLastBan>0</DaysSinceLastBan>
+						<NumberOfGameBans>0</NumberOfGameBans>
+						<EconomyBan>none</EconomyBan>
+					</player>
+					<player>
+						<SteamId>76561197960287930</SteamId>
+						<CommunityBanned>true</CommunityBanned>
+						<VACBanned>true</VACBanned>
+						<NumberOfVACBans>2</NumberOfVACBans>
+						<DaysSinceLastBan>731</DaysSinceLastBan>
+						<NumberOfGameBans>1</NumberOfGameBans>
+						<EconomyBan>probation</EconomyBan>
+					</player>
+				</players>
+			</response>`,
+			want:    want,
+			wantErr: false,
+		},
+		{
+			name: "TestGetPlayerBans 3 - VDF",
+			params: GetPlayerBansParams{
+				SteamIds: []steamid.ID{76561197960435530, 76561197960287930},
+				Format:   config.Vdf,
+			},
+			response: `"players"
+			{
+				"0"
+				{
+					"SteamId"	"76561197960435530"
+					"CommunityBanned"	"0"
+					"VACBanned"	"0"
+					"NumberOfVACBans"	"0"
+					"DaysSinceLastBan"	"0"
+					"NumberOfGameBans"	"0"
+					"EconomyBan"	"none"
+				}
+				"1"
+				{
+					"SteamId"	"76561197960287930"
+					"CommunityBanned"	"1"
+					"VACBanned"	"1"
+					"NumberOfVACBans"	"2"
+					"DaysSinceLastBan"	"731"
+					"NumberOfGameBans"	"1"
+					"EconomyBan"	"probation"
+				}
+			}`,
+			want:    want,
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamUser/GetPlayerBans/v1",
+				func(req *http.Request) (*http.Response, error) {
+					steamIds := make([]string, len(tc.params.SteamIds))
+					for i, v := range tc.params.SteamIds {
+						steamIds[i] = v.String()
+					}
+
+					if req.URL.Query().Get("key") != "test-key" ||
+						req.URL.Query().Get("steamids") != strings.Join(steamIds, ",") ||
+						req.URL.Query().Get("format") != tc.params.Format.String() {
+						t.Errorf("Request parameters do not match")
+					}
+					return httpmock.NewStringResponse(200, tc.response), nil
+				})
+
+			api := New("test-key", &http.Client{})
+			got, err := api.GetPlayerBans(tc.params)
+
+			if (err != nil) != tc.wantErr {
+				t.Errorf("GetPlayerBans() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("GetPlayerBans() got = %v , expected %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetPlayerBansErrors(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	tooManyIds := make([]steamid.ID, 101)
+	for i := range tooManyIds {
+		tooManyIds[i] = steamid.FromAccountID(uint32(i + 1))
+	}
+
+	testCases := []struct {
+		name   string
+		client *Client
+		params GetPlayerBansParams
+		want   error
+	}{
+		{
+			name:   "TestGetPlayerBansErrors 1 - No Key",
+			client: NewClientWithoutKey(http.DefaultClient),
+			params: GetPlayerBansParams{SteamIds: []steamid.ID{76561197960435530}},
+			want:   ErrKeyRequired,
+		},
+		{
+			name:   "TestGetPlayerBansErrors 2 - Too many IDs",
+			client: New("test-key", http.DefaultClient),
+			params: GetPlayerBansParams{SteamIds: tooManyIds},
+			want:   ErrTooManyIDs,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.client.GetPlayerBans(tc.params)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("GetPlayerBans() error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
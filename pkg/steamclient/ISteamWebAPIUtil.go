@@ -0,0 +1,132 @@
+package steamclient
+
+import (
+	"context"
+	"net/url"
+
+	urlHelper "github.com/xemkayx/steam-api/internal/urlHelper"
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+	model "github.com/xemkayx/steam-api/pkg/steamclient/model/ISteamWebAPIUtil"
+)
+
+const (
+	ISteamWebAPIUtil            = "ISteamWebAPIUtil"
+	GetSupportedAPIListEndpoint = "GetSupportedAPIList" // v0001
+	GetServerInfoEndpoint       = "GetServerInfo"       // v0001
+)
+
+// Parameters for the GetSupportedAPIList method
+type GetSupportedAPIListParams struct {
+	Format config.OutputFormat // Format of the output
+}
+
+// Parameters for the GetServerInfo method
+type GetServerInfoParams struct {
+	Format config.OutputFormat // Format of the output
+}
+
+/*
+GetSupportedAPIList returns the interfaces and methods of the Web API with their versions and parameters.
+Without a key only the public methods are listed, with a key also the ones it has access to.
+
+Arguments
+  - format
+    Output format. json (default), xml or vdf.
+*/
+func (c Client) GetSupportedAPIList(params GetSupportedAPIListParams) (*model.SupportedAPIList, error) {
+	return c.GetSupportedAPIListCtx(context.Background(), params)
+}
+
+// GetSupportedAPIListCtx is like GetSupportedAPIList but sends the request with the given context.
+func (c Client) GetSupportedAPIListCtx(ctx context.Context, params GetSupportedAPIListParams) (*model.SupportedAPIList, error) {
+	version := "1"
+
+	vals := url.Values{}
+	vals.Set("format", params.Format.String())
+
+	if c.IsKeySet() {
+		vals.Set("key", c.Key)
+	}
+
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetSupportedAPIListEndpoint, Version: version}
+	resp, err := c.get(ctx, ISteamWebAPIUtil, versUrlEndpoint, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	switch params.Format {
+	case config.Json:
+		var result model.SupportedAPIListWrapper
+		if _, err := decodeJSON(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.APIList, nil
+
+	case config.Xml:
+		var result model.SupportedAPIList
+		if _, err := decodeXML(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result, nil
+
+	case config.Vdf:
+		var result model.SupportedAPIListWrapper
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.APIList, nil
+
+	default:
+		return nil, unsupportedFormatError(params.Format)
+	}
+}
+
+/*
+GetServerInfo returns the current time of the Web API servers. It's useful to check if the API is reachable.
+
+Arguments
+  - format
+    Output format. json (default), xml or vdf.
+*/
+func (c Client) GetServerInfo(params GetServerInfoParams) (*model.ServerInfo, error) {
+	return c.GetServerInfoCtx(context.Background(), params)
+}
+
+// GetServerInfoCtx is like GetServerInfo but sends the request with the given context.
+func (c Client) GetServerInfoCtx(ctx context.Context, params GetServerInfoParams) (*model.ServerInfo, error) {
+	version := "1"
+
+	vals := url.Values{}
+	vals.Set("format", params.Format.String())
+
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetServerInfoEndpoint, Version: version}
+	resp, err := c.get(ctx, ISteamWebAPIUtil, versUrlEndpoint, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	// the JSON answer has no "response" object around it, the XML and VDF ones have
+	var result model.ServerInfo
+	if err := decodeUnwrapped(params.Format, resp.Body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+/*
+IsEndpointListed reports whether the version of the method of the interface is in the list, so it can still be
+called. Together with a recorded GetSupportedAPIList response it detects deprecated endpoint versions:
+
+	if !IsEndpointListed(list, ISteamUser, GetPlayerSummariesEndpoint, 2) { ... }
+*/
+func IsEndpointListed(list *model.SupportedAPIList, iface, method string, version int) bool {
+	if list == nil {
+		return false
+	}
+	_, ok := list.Method(iface, method, version)
+	return ok
+}
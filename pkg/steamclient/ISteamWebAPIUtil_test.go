@@ -0,0 +1,263 @@
+package steamclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+	model "github.com/xemkayx/steam-api/pkg/steamclient/model/ISteamWebAPIUtil"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// loadSupportedAPIList reads the recorded GetSupportedAPIList response in testdata
+func loadSupportedAPIList(t *testing.T) *model.SupportedAPIList {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "supported_api_list.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result model.SupportedAPIListWrapper
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	return &result.APIList
+}
+
+func TestGetSupportedAPIList(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	fixture, err := os.ReadFile(filepath.Join("testdata", "supported_api_list.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolveVanityURL := model.Method{
+		Name:       "ResolveVanityURL",
+		Version:    1,
+		HTTPMethod: "GET",
+		Parameters: []model.Parameter{
+			{Name: "key", Type: "string", Description: "access key"},
+			{Name: "vanityurl", Type: "string", Description: "The vanity URL to get a SteamID for"},
+			{Name: "url_type", Type: "int32", Optional: true, Description: "The type of vanity URL. 1 (default): Individual profile, 2: Group, 3: Official game group"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		format   config.OutputFormat
+		response string
+	}{
+		{name: "JSON", format: config.Json, response: string(fixture)},
+		{name: "XML", format: config.Xml, response: `<?xml version="1.0" encoding="UTF-8"?>
+			<!DOCTYPE apilist>
+			<apilist>
+				<interfaces>
+					<interface>
+						<name>ISteamUser</name>
+						<methods>
+							<method>
+								<name>ResolveVanityURL</name>
+								<version>1</version>
+								<httpmethod>GET</httpmethod>
+								<parameters>
+									<parameter><name>key</name><type>string</type><optional>false</optional><description>access key</description></parameter>
+									<parameter><name>vanityurl</name><type>string</type><optional>false</optional><description>The vanity URL to get a SteamID for</description></parameter>
+									<parameter><name>url_type</name><type>int32</type><optional>true</optional><description>The type of vanity URL. 1 (default): Individual profile, 2: Group, 3: Official game group</description></parameter>
+								</parameters>
+							</method>
+						</methods>
+					</interface>
+					<interface>
+						<name>ISteamWebAPIUtil</name>
+						<methods>
+							<method><name>GetServerInfo</name><version>1</version><httpmethod>GET</httpmethod><parameters></parameters></method>
+						</methods>
+					</interface>
+				</interfaces>
+			</apilist>`},
+		{name: "VDF", format: config.Vdf, response: `"apilist"
+			{
+				"interfaces"
+				{
+					"0"
+					{
+						"name"	"ISteamUser"
+						"methods"
+						{
+							"0"
+							{
+								"name"	"ResolveVanityURL"
+								"version"	"1"
+								"httpmethod"	"GET"
+								"parameters"
+								{
+									"0"
+									{
+										"name"	"key"
+										"type"	"string"
+										"optional"	"0"
+										"description"	"access key"
+									}
+									"1"
+									{
+										"name"	"vanityurl"
+										"type"	"string"
+										"optional"	"0"
+										"description"	"The vanity URL to get a SteamID for"
+									}
+									"2"
+									{
+										"name"	"url_type"
+										"type"	"int32"
+										"optional"	"1"
+										"description"	"The type of vanity URL. 1 (default): Individual profile, 2: Group, 3: Official game group"
+									}
+								}
+							}
+						}
+					}
+					"1"
+					{
+						"name"	"ISteamWebAPIUtil"
+						"methods"
+						{
+							"0"
+							{
+								"name"	"GetServerInfo"
+								"version"	"1"
+								"httpmethod"	"GET"
+								"parameters"
+								{
+								}
+							}
+						}
+					}
+				}
+			}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamWebAPIUtil/GetSupportedAPIList/v1",
+				func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, tt.format.String(), req.URL.Query().Get("format"))
+					assert.Equal(t, "test-key", req.URL.Query().Get("key"))
+					return httpmock.NewStringResponse(200, tt.response), nil
+				})
+
+			client := New("test-key", &http.Client{})
+			got, err := client.GetSupportedAPIList(GetSupportedAPIListParams{Format: tt.format})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			method, ok := got.Method(ISteamUser, ResolveVanityURLEndpoint, 1)
+			assert.True(t, ok)
+			assert.Equal(t, resolveVanityURL, method)
+
+			method, ok = got.Method(ISteamWebAPIUtil, GetServerInfoEndpoint, 1)
+			assert.True(t, ok)
+			assert.Equal(t, "GET", method.HTTPMethod)
+			assert.Empty(t, method.Parameters)
+
+			_, ok = got.Method(ISteamUser, ResolveVanityURLEndpoint, 2)
+			assert.False(t, ok)
+			_, ok = got.Interface("IUnknown")
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestGetServerInfo(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	want := &model.ServerInfo{ServerTime: 1792224000, ServerTimeString: "Sat Oct 17 00:00:00 2026"}
+
+	tests := []struct {
+		name     string
+		format   config.OutputFormat
+		response string
+	}{
+		{name: "JSON", format: config.Json, response: `{"servertime":1792224000,"servertimestring":"Sat Oct 17 00:00:00 2026"}`},
+		{name: "XML", format: config.Xml, response: `<?xml version="1.0" encoding="UTF-8"?>
+			<!DOCTYPE response>
+			<response>
+				<servertime>1792224000</servertime>
+				<servertimestring>Sat Oct 17 00:00:00 2026</servertimestring>
+			</response>`},
+		{name: "VDF", format: config.Vdf, response: `"response"
+			{
+				"servertime"	"1792224000"
+				"servertimestring"	"Sat Oct 17 00:00:00 2026"
+			}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamWebAPIUtil/GetServerInfo/v1",
+				httpmock.NewStringResponder(200, tt.response))
+
+			client := NewClientWithoutKey(&http.Client{})
+			got, err := client.GetServerInfo(GetServerInfoParams{Format: tt.format})
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestIsEndpointListed(t *testing.T) {
+	list := loadSupportedAPIList(t)
+
+	assert.True(t, IsEndpointListed(list, ISteamUser, GetPlayerSummariesEndpoint, 2))
+	assert.False(t, IsEndpointListed(list, ISteamUser, GetPlayerSummariesEndpoint, 3))
+	assert.False(t, IsEndpointListed(list, ISteamNews, GetPlayerSummariesEndpoint, 2))
+	assert.False(t, IsEndpointListed(list, ISteamUser, "GetPlayerSummary", 2))
+	assert.False(t, IsEndpointListed(nil, ISteamUser, GetPlayerSummariesEndpoint, 2))
+}
+
+// TestEndpointsListed fails when an endpoint version the client calls is no longer listed by Steam.
+// Update testdata/supported_api_list.json with a new recording of GetSupportedAPIList to check the current state.
+func TestEndpointsListed(t *testing.T) {
+	list := loadSupportedAPIList(t)
+
+	endpoints := []struct {
+		iface   string
+		method  string
+		version int
+	}{
+		{IPlayerService, GetOwnedGamesEndpoint, 1},
+		{IPlayerService, GetRecentlyPlayedGamesEndpoint, 1},
+		{IPlayerService, GetSteamLevelEndpoint, 1},
+		{IPlayerService, GetBadgesEndpoint, 1},
+		{IPlayerService, GetCommunityBadgeProgressEndpoint, 1},
+		{IPlayerService, IsPlayingSharedGameEndpoint, 1},
+		{ISteamApps, GetAppListEndpoint, 2},
+		{ISteamApps, GetServersAtAddressEndpoint, 1},
+		{ISteamApps, UpToDateCheckEndpoint, 1},
+		{ISteamNews, GetNewsForAppEndpoint, 2},
+		{ISteamUser, GetPlayerSummariesEndpoint, 2},
+		{ISteamUser, GetFriendListEndpoint, 1},
+		{ISteamUser, GetPlayerBansEndpoint, 1},
+		{ISteamUser, ResolveVanityURLEndpoint, 1},
+		{ISteamUserStats, GetGlobalAchievementPercentagesForAppEndpoint, 2},
+		{ISteamUserStats, GetGlobalStatsForGameEndpoint, 1},
+		{ISteamUserStats, GetNumberOfCurrentPlayersEndpoint, 1},
+		{ISteamUserStats, GetPlayerAchievementsEndpoint, 1},
+		{ISteamUserStats, GetSchemaForGameEndpoint, 2},
+		{ISteamUserStats, GetUserStatsForGameEndpoint, 2},
+		{IStoreService, GetStoreAppListEndpoint, 1},
+		{ISteamWebAPIUtil, GetServerInfoEndpoint, 1},
+		{ISteamWebAPIUtil, GetSupportedAPIListEndpoint, 1},
+	}
+
+	for _, e := range endpoints {
+		assert.True(t, IsEndpointListed(list, e.iface, e.method, e.version), "%s/%s/v%d is not listed anymore", e.iface, e.method, e.version)
+	}
+}
@@ -0,0 +1,246 @@
+package steamclient
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	urlHelper "github.com/xemkayx/steam-api/internal/urlHelper"
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+	model "github.com/xemkayx/steam-api/pkg/steamclient/model/IStoreService"
+)
+
+const (
+	IStoreService           = "IStoreService"
+	GetStoreAppListEndpoint = "GetAppList" // v0001
+)
+
+// Parameters for the GetStoreAppList method
+type GetStoreAppListParams struct {
+	IfModifiedSince         *uint32             // (optional) Return only apps whose description, title or tags changed after this time (unix epoch timestamp)
+	HaveDescriptionLanguage *string             // (optional) Return only apps that have a description in this language
+	Language                *string             // (optional) Return data in this language (default: english)
+	IncludeGames            *bool               // (optional) Include games (default: true)
+	IncludeDLC              *bool               // (optional) Include DLC
+	IncludeSoftware         *bool               // (optional) Include software
+	IncludeVideos           *bool               // (optional) Include videos and series
+	IncludeHardware         *bool               // (optional) Include hardware
+	LastAppId               *uint32             // (optional) Return the apps after this AppID, the LastAppId of the previous page
+	MaxResults              *uint32             // (optional) Number of apps per page (default: 10000, max: 50000)
+	Format                  config.OutputFormat // Format of the output
+}
+
+/*
+GetStoreAppList returns one page of the apps on the Steam Store, ordered by AppID. Unlike ISteamApps/GetAppList
+it can be filtered and paged; StoreAppList pages through all of them.
+
+# Key required
+
+Arguments
+  - if_modified_since
+    Return only items that have a description, title or tag change after this time.
+  - have_description_language
+    Return only items that have a description in this language.
+  - language
+    Return data in this language (default: english).
+  - include_games
+    Include games (default: true).
+  - include_dlc
+    Include DLC.
+  - include_software
+    Include software items.
+  - include_videos
+    Include videos and series.
+  - include_hardware
+    Include hardware.
+  - last_appid
+    For continuations, the last AppID returned by the previous call.
+  - max_results
+    Number of results to return at a time. Default 10k, max 50k.
+  - format
+    Output format. json (default), xml or vdf.
+*/
+func (c Client) GetStoreAppList(params GetStoreAppListParams) (*model.AppList, error) {
+	return c.GetStoreAppListCtx(context.Background(), params)
+}
+
+// GetStoreAppListCtx is like GetStoreAppList but sends the request with the given context.
+func (c Client) GetStoreAppListCtx(ctx context.Context, params GetStoreAppListParams) (*model.AppList, error) {
+	if !c.IsKeySet() {
+		return nil, ErrKeyRequired
+	}
+	if err := checkTextFormat(params.Format); err != nil {
+		return nil, err
+	}
+	version := "1"
+
+	vals := url.Values{}
+	vals.Set("key", c.Key)
+	vals.Set("format", params.Format.String())
+
+	if params.IfModifiedSince != nil {
+		vals.Set("if_modified_since", strconv.FormatUint(uint64(*params.IfModifiedSince), 10))
+	}
+	if params.HaveDescriptionLanguage != nil {
+		vals.Set("have_description_language", *params.HaveDescriptionLanguage)
+	}
+	if params.Language != nil {
+		vals.Set("language", *params.Language)
+	}
+	if params.IncludeGames != nil {
+		vals.Set("include_games", strconv.FormatBool(*params.IncludeGames))
+	}
+	if params.IncludeDLC != nil {
+		vals.Set("include_dlc", strconv.FormatBool(*params.IncludeDLC))
+	}
+	if params.IncludeSoftware != nil {
+		vals.Set("include_software", strconv.FormatBool(*params.IncludeSoftware))
+	}
+	if params.IncludeVideos != nil {
+		vals.Set("include_videos", strconv.FormatBool(*params.IncludeVideos))
+	}
+	if params.IncludeHardware != nil {
+		vals.Set("include_hardware", strconv.FormatBool(*params.IncludeHardware))
+	}
+	if params.LastAppId != nil {
+		vals.Set("last_appid", strconv.FormatUint(uint64(*params.LastAppId), 10))
+	}
+	if params.MaxResults != nil {
+		vals.Set("max_results", strconv.FormatUint(uint64(*params.MaxResults), 10))
+	}
+
+	versUrlEndpoint := urlHelper.VersionedURLEndpoint{EndpointPath: GetStoreAppListEndpoint, Version: version}
+	resp, err := c.get(ctx, IStoreService, versUrlEndpoint, vals)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	switch params.Format {
+	case config.Json:
+		var result model.AppListWrapper
+		if _, err := decodeJSON(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.Response, nil
+
+	case config.Xml:
+		var result model.AppList
+		if _, err := decodeXML(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result, nil
+
+	case config.Vdf:
+		var result model.AppListWrapper
+		if _, err := decodeVDF(&result, resp.Body); err != nil {
+			return nil, err
+		}
+		return &result.Response, nil
+
+	default:
+		return nil, unsupportedFormatError(params.Format)
+	}
+}
+
+/*
+StoreAppListIterator pages through IStoreService/GetAppList. Pages are requested as the apps are consumed:
+
+	it := client.StoreAppList(ctx, steamclient.GetStoreAppListParams{})
+	for it.Next() {
+		app := it.App()
+		...
+	}
+	if err := it.Err(); err != nil {
+		// resume later with LastAppId: it.LastAppId()
+	}
+
+For an incremental sync, save the time the previous sync started and pass it as IfModifiedSince, so only apps
+changed since then are listed. An interrupted sync continues after the last consumed app if LastAppId is set to
+the saved it.LastAppId().
+
+A StoreAppListIterator is not safe for concurrent use.
+*/
+type StoreAppListIterator struct {
+	client Client
+	ctx    context.Context
+	params GetStoreAppListParams
+
+	page     []model.App
+	pos      int // index of the current app in page + 1
+	cursor   uint32
+	started  bool
+	haveMore bool
+	err      error
+}
+
+// StoreAppList returns an iterator over all apps GetStoreAppList lists for params.
+// params.LastAppId is the starting point; params.Format is ignored.
+func (c Client) StoreAppList(ctx context.Context, params GetStoreAppListParams) *StoreAppListIterator {
+	it := &StoreAppListIterator{client: c, ctx: ctx, params: params}
+	if params.LastAppId != nil {
+		it.cursor = *params.LastAppId
+	}
+	return it
+}
+
+// Next advances to the next app, requesting the next page if needed. It returns false at the end of the list
+// or if a request failed, see Err.
+func (it *StoreAppListIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for it.pos >= len(it.page) {
+		if it.started && !it.haveMore {
+			return false
+		}
+		if !it.fetch() {
+			return false
+		}
+	}
+	it.pos++
+	it.cursor = it.page[it.pos-1].AppId
+	return true
+}
+
+// fetch requests the page after the cursor
+func (it *StoreAppListIterator) fetch() bool {
+	params := it.params
+	params.Format = config.Json
+	if it.started || it.params.LastAppId != nil {
+		cursor := it.cursor
+		params.LastAppId = &cursor
+	}
+
+	list, err := it.client.GetStoreAppListCtx(it.ctx, params)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.started = true
+	it.page = list.Apps
+	it.pos = 0
+	// an empty page with more results would never end
+	it.haveMore = list.HaveMoreResults && len(list.Apps) > 0
+	return true
+}
+
+// App returns the current app
+func (it *StoreAppListIterator) App() model.App {
+	if it.pos == 0 {
+		return model.App{}
+	}
+	return it.page[it.pos-1]
+}
+
+// LastAppId returns the AppID of the current app, to resume the iteration after it later
+func (it *StoreAppListIterator) LastAppId() uint32 {
+	return it.cursor
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *StoreAppListIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,193 @@
+package steamclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+	model "github.com/xemkayx/steam-api/pkg/steamclient/model/IStoreService"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStoreAppList(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	since := uint32(1700000000)
+	lastAppId := uint32(10)
+	maxResults := uint32(2)
+	no := false
+	yes := true
+
+	want := &model.AppList{
+		Apps: []model.App{
+			{AppId: 20, Name: "Team Fortress Classic", LastModified: 1579634708, PriceChangeNumber: 23090138},
+			{AppId: 30, Name: "Day of Defeat", LastModified: 1512413490, PriceChangeNumber: 23090138},
+		},
+		HaveMoreResults: true,
+		LastAppId:       30,
+	}
+
+	tests := []struct {
+		name     string
+		format   config.OutputFormat
+		response string
+	}{
+		{name: "JSON", format: config.Json, response: `{"response":{"apps":[
+			{"appid":20,"name":"Team Fortress Classic","last_modified":1579634708,"price_change_number":23090138},
+			{"appid":30,"name":"Day of Defeat","last_modified":1512413490,"price_change_number":23090138}],
+			"have_more_results":true,"last_appid":30}}`},
+		{name: "XML", format: config.Xml, response: `<?xml version="1.0" encoding="UTF-8"?>
+			<!DOCTYPE response>
+			<response>
+				<apps>
+					<message><appid>20</appid><name>Team Fortress Classic</name><last_modified>1579634708</last_modified><price_change_number>23090138</price_change_number></message>
+					<message><appid>30</appid><name>Day of Defeat</name><last_modified>1512413490</last_modified><price_change_number>23090138</price_change_number></message>
+				</apps>
+				<have_more_results>true</have_more_results>
+				<last_appid>30</last_appid>
+			</response>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/IStoreService/GetAppList/v1",
+				func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, url.Values{
+						"key":               {"test-key"},
+						"format":            {tt.format.String()},
+						"if_modified_since": {"1700000000"},
+						"include_games":     {"false"},
+						"include_dlc":       {"true"},
+						"last_appid":        {"10"},
+						"max_results":       {"2"},
+					}, req.URL.Query())
+					return httpmock.NewStringResponse(200, tt.response), nil
+				})
+
+			client := New("test-key", &http.Client{})
+			got, err := client.GetStoreAppList(GetStoreAppListParams{
+				IfModifiedSince: &since,
+				IncludeGames:    &no,
+				IncludeDLC:      &yes,
+				LastAppId:       &lastAppId,
+				MaxResults:      &maxResults,
+				Format:          tt.format,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	_, err := NewClientWithoutKey(&http.Client{}).GetStoreAppList(GetStoreAppListParams{})
+	assert.ErrorIs(t, err, ErrKeyRequired)
+}
+
+// registerStoreAppList answers GetAppList with pages of size apps of the AppIDs 10, 20, ..., 10*total;
+// a request for the page after failAfter fails
+func registerStoreAppList(t *testing.T, total, size int, failAfter uint32) {
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/IStoreService/GetAppList/v1",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "json", req.URL.Query().Get("format"))
+			assert.Equal(t, "1700000000", req.URL.Query().Get("if_modified_since"))
+
+			last, _ := strconv.Atoi(req.URL.Query().Get("last_appid"))
+			if failAfter != 0 && uint32(last) == failAfter {
+				return httpmock.NewStringResponse(503, ""), nil
+			}
+
+			var page model.AppList
+			for id := last/10*10 + 10; id <= 10*total && len(page.Apps) < size; id += 10 {
+				page.Apps = append(page.Apps, model.App{AppId: uint32(id), Name: fmt.Sprintf("App %d", id)})
+			}
+			if len(page.Apps) > 0 {
+				page.LastAppId = page.Apps[len(page.Apps)-1].AppId
+				page.HaveMoreResults = int(page.LastAppId) < 10*total
+			}
+			return httpmock.NewJsonResponse(200, model.AppListWrapper{Response: page})
+		})
+}
+
+func TestStoreAppListIterator(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	since := uint32(1700000000)
+	client := New("test-key", &http.Client{})
+
+	t.Run("all pages", func(t *testing.T) {
+		httpmock.Reset()
+		registerStoreAppList(t, 5, 2, 0)
+
+		it := client.StoreAppList(context.Background(), GetStoreAppListParams{IfModifiedSince: &since, Format: config.Xml})
+		var ids []uint32
+		for it.Next() {
+			ids = append(ids, it.App().AppId)
+			assert.Equal(t, it.App().AppId, it.LastAppId())
+		}
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []uint32{10, 20, 30, 40, 50}, ids)
+		assert.Equal(t, 3, httpmock.GetTotalCallCount())
+		assert.False(t, it.Next(), "a finished iterator stays finished")
+		assert.Equal(t, 3, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		httpmock.Reset()
+		registerStoreAppList(t, 0, 2, 0)
+
+		it := client.StoreAppList(context.Background(), GetStoreAppListParams{IfModifiedSince: &since})
+		assert.False(t, it.Next())
+		assert.NoError(t, it.Err())
+		assert.Equal(t, model.App{}, it.App())
+	})
+
+	t.Run("resume after error", func(t *testing.T) {
+		httpmock.Reset()
+		registerStoreAppList(t, 5, 2, 40)
+
+		it := client.StoreAppList(context.Background(), GetStoreAppListParams{IfModifiedSince: &since})
+		var ids []uint32
+		for it.Next() {
+			ids = append(ids, it.App().AppId)
+		}
+		assert.ErrorAs(t, it.Err(), new(*APIError))
+		assert.Equal(t, []uint32{10, 20, 30, 40}, ids)
+
+		httpmock.Reset()
+		registerStoreAppList(t, 5, 2, 0)
+
+		lastAppId := it.LastAppId()
+		it = client.StoreAppList(context.Background(), GetStoreAppListParams{IfModifiedSince: &since, LastAppId: &lastAppId})
+		ids = nil
+		for it.Next() {
+			ids = append(ids, it.App().AppId)
+		}
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []uint32{50}, ids)
+	})
+
+	t.Run("protobuf", func(t *testing.T) {
+		httpmock.Reset()
+		registerStoreAppList(t, 5, 2, 0)
+
+		_, err := client.GetStoreAppList(GetStoreAppListParams{IfModifiedSince: &since, Format: config.Protobuf})
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+		assert.Equal(t, 0, httpmock.GetTotalCallCount(), "protobuf is rejected before sending")
+
+		// the iterator ignores the format and always pages in JSON
+		it := client.StoreAppList(context.Background(), GetStoreAppListParams{IfModifiedSince: &since, Format: config.Protobuf})
+		var ids []uint32
+		for it.Next() {
+			ids = append(ids, it.App().AppId)
+		}
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []uint32{10, 20, 30, 40, 50}, ids)
+	})
+}
@@ -0,0 +1,108 @@
+package steamclient
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+	model "github.com/xemkayx/steam-api/pkg/steamclient/model/ISteamApps"
+)
+
+/*
+AppIndex maps AppIDs to app names and back. It's built once from the app list and safe for concurrent use:
+
+	index, err := client.LoadAppIndex(ctx)
+	name, ok := index.Name(440) // Team Fortress 2
+	apps := index.Search("half-life")
+*/
+type AppIndex struct {
+	byID  map[uint32]model.App
+	apps  []model.App // sorted by AppID
+	lower []string    // lower-cased names of apps
+}
+
+// NewAppIndex builds an index of apps, e.g. of a saved app list. Apps without a name are left out;
+// if an AppID is listed more than once, its first name is kept.
+func NewAppIndex(apps []model.App) *AppIndex {
+	idx := &AppIndex{byID: make(map[uint32]model.App, len(apps))}
+	for _, app := range apps {
+		if app.Name == "" {
+			continue
+		}
+		if _, ok := idx.byID[app.AppId]; ok {
+			continue
+		}
+		idx.byID[app.AppId] = app
+		idx.apps = append(idx.apps, app)
+	}
+
+	sort.Slice(idx.apps, func(i, j int) bool { return idx.apps[i].AppId < idx.apps[j].AppId })
+	idx.lower = make([]string, len(idx.apps))
+	for i, app := range idx.apps {
+		idx.lower[i] = strings.ToLower(app.Name)
+	}
+	return idx
+}
+
+// LoadAppIndex loads the app list with GetAppList and builds an index of it
+func (c Client) LoadAppIndex(ctx context.Context) (*AppIndex, error) {
+	list, err := c.GetAppListCtx(ctx, GetAppListParams{Format: config.Json})
+	if err != nil {
+		return nil, err
+	}
+	return NewAppIndex(list.Apps), nil
+}
+
+// Len returns the number of apps in the index
+func (idx *AppIndex) Len() int {
+	return len(idx.apps)
+}
+
+// App returns the app with the AppID
+func (idx *AppIndex) App(appID uint32) (model.App, bool) {
+	app, ok := idx.byID[appID]
+	return app, ok
+}
+
+// Name returns the name of the app with the AppID
+func (idx *AppIndex) Name(appID uint32) (string, bool) {
+	app, ok := idx.byID[appID]
+	return app.Name, ok
+}
+
+// Find returns the app whose name is name, ignoring case. If several apps have that name, the one with the
+// lowest AppID is returned.
+func (idx *AppIndex) Find(name string) (model.App, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i, lower := range idx.lower {
+		if lower == name {
+			return idx.apps[i], true
+		}
+	}
+	return model.App{}, false
+}
+
+/*
+Search returns the apps whose name contains query, ignoring case. Exact matches come first, followed by names
+starting with query and then all others; each group is sorted by AppID. An empty query matches nothing.
+*/
+func (idx *AppIndex) Search(query string) []model.App {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	var exact, prefix, contains []model.App
+	for i, lower := range idx.lower {
+		switch {
+		case lower == query:
+			exact = append(exact, idx.apps[i])
+		case strings.HasPrefix(lower, query):
+			prefix = append(prefix, idx.apps[i])
+		case strings.Contains(lower, query):
+			contains = append(contains, idx.apps[i])
+		}
+	}
+	return append(append(exact, prefix...), contains...)
+}
@@ -0,0 +1,77 @@
+package steamclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	model "github.com/xemkayx/steam-api/pkg/steamclient/model/ISteamApps"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppIndex(t *testing.T) {
+	idx := NewAppIndex([]model.App{
+		{AppId: 420, Name: "Half-Life 2: Episode Two"},
+		{AppId: 70, Name: "Half-Life"},
+		{AppId: 220, Name: "Half-Life 2"},
+		{AppId: 440, Name: "Team Fortress 2"},
+		{AppId: 440, Name: "Team Fortress 2 Duplicate"},
+		{AppId: 999, Name: ""},
+		{AppId: 546560, Name: "Half-Life: Alyx"},
+		{AppId: 1, Name: "half-life"},
+	})
+
+	assert.Equal(t, 6, idx.Len())
+
+	name, ok := idx.Name(440)
+	assert.True(t, ok)
+	assert.Equal(t, "Team Fortress 2", name, "the first name of an AppID is kept")
+	_, ok = idx.Name(999)
+	assert.False(t, ok, "apps without a name are left out")
+
+	app, ok := idx.App(220)
+	assert.True(t, ok)
+	assert.Equal(t, model.App{AppId: 220, Name: "Half-Life 2"}, app)
+
+	app, ok = idx.Find(" HALF-LIFE ")
+	assert.True(t, ok)
+	assert.Equal(t, uint32(1), app.AppId, "the lowest AppID wins")
+	_, ok = idx.Find("Half")
+	assert.False(t, ok)
+
+	var ids []uint32
+	for _, app := range idx.Search("half-life") {
+		ids = append(ids, app.AppId)
+	}
+	assert.Equal(t, []uint32{1, 70, 220, 420, 546560}, ids)
+
+	ids = nil
+	for _, app := range idx.Search("2") {
+		ids = append(ids, app.AppId)
+	}
+	assert.Equal(t, []uint32{220, 420, 440}, ids)
+
+	assert.Empty(t, idx.Search(""))
+	assert.Empty(t, idx.Search("portal"))
+}
+
+func TestLoadAppIndex(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamApps/GetAppList/v2",
+		httpmock.NewStringResponder(200, `{"applist":{"apps":[{"appid":440,"name":"Team Fortress 2"},{"appid":570,"name":"Dota 2"}]}}`))
+
+	client := NewClientWithoutKey(&http.Client{})
+	idx, err := client.LoadAppIndex(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, idx.Len())
+	name, _ := idx.Name(570)
+	assert.Equal(t, "Dota 2", name)
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamApps/GetAppList/v2", httpmock.NewStringResponder(500, ""))
+	_, err = client.LoadAppIndex(context.Background())
+	assert.Error(t, err)
+}
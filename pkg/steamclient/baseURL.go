@@ -0,0 +1,67 @@
+package steamclient
+
+import (
+	"context"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/constant"
+)
+
+// Interfaces that only exist on the partner host
+var publisherOnlyInterfaces = map[string]bool{
+	"ICheatReportingService": true,
+	"ISteamGameServerStats":  true,
+	"ISteamLeaderboards":     true,
+	"ISteamMicroTxn":         true,
+	"ISteamMicroTxnSandbox":  true,
+}
+
+// Methods of public interfaces that only exist on the partner host, keyed by "Interface/Method"
+var publisherOnlyMethods = map[string]bool{
+	"ISteamApps/GetAppBetas":                     true,
+	"ISteamApps/GetAppBuilds":                    true,
+	"ISteamApps/GetAppDepotVersions":             true,
+	"ISteamApps/GetCheatingReports":              true,
+	"ISteamApps/GetPartnerAppListForWebAPIKey":   true,
+	"ISteamApps/GetPlayersBanned":                true,
+	"ISteamApps/SetAppBuildLive":                 true,
+	"ISteamUser/CheckAppOwnership":               true,
+	"ISteamUser/GetAppPriceInfo":                 true,
+	"ISteamUser/GetDeletedSteamIDs":              true,
+	"ISteamUser/GetPublisherAppOwnership":        true,
+	"ISteamUser/GetPublisherAppOwnershipChanges": true,
+	"ISteamUserStats/SetUserStatsForGame":        true,
+}
+
+// IsPublisherOnly reports whether a method can only be called on the partner host with a publisher key
+func IsPublisherOnly(interf, method string) bool {
+	return publisherOnlyInterfaces[interf] || publisherOnlyMethods[interf+"/"+method]
+}
+
+type baseURLKey struct{}
+
+// WithBaseURL returns a context that sends the requests made with it to baseURL,
+// instead of the base URL configured in the Client
+func WithBaseURL(ctx context.Context, baseURL string) context.Context {
+	return context.WithValue(ctx, baseURLKey{}, baseURL)
+}
+
+/*
+baseURL returns the base URL a request to the given method is sent to:
+the one of the context, the partner host for publisher-only methods if the Client has a publisher key,
+or the base URL of the Client.
+*/
+func (c Client) baseURL(ctx context.Context, interf, method string) string {
+	if baseURL, ok := ctx.Value(baseURLKey{}).(string); ok && baseURL != "" {
+		return baseURL
+	}
+	if c.PublisherKey && IsPublisherOnly(interf, method) {
+		if c.PartnerBaseURL != "" {
+			return c.PartnerBaseURL
+		}
+		return constant.SteamPartnerApiBaseURL
+	}
+	if c.BaseURL != "" {
+		return c.BaseURL
+	}
+	return constant.SteamWebApiBaseURL
+}
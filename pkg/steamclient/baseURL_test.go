@@ -0,0 +1,105 @@
+package steamclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientBaseURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		client Client
+		ctx    context.Context
+		interf string
+		method string
+		want   string
+	}{
+		{
+			name:   "default",
+			interf: ISteamUser, method: GetFriendListEndpoint,
+			want: "https://api.steampowered.com",
+		},
+		{
+			name:   "client base URL",
+			client: Client{BaseURL: "http://localhost:8080"},
+			interf: ISteamUser, method: GetFriendListEndpoint,
+			want: "http://localhost:8080",
+		},
+		{
+			name:   "context base URL",
+			client: Client{BaseURL: "http://localhost:8080", PublisherKey: true},
+			ctx:    WithBaseURL(context.Background(), "http://proxy"),
+			interf: ISteamUserStats, method: SetUserStatsForGameEndpoint,
+			want: "http://proxy",
+		},
+		{
+			name:   "publisher-only method",
+			client: Client{BaseURL: "http://localhost:8080", PublisherKey: true},
+			interf: ISteamUserStats, method: SetUserStatsForGameEndpoint,
+			want: "https://partner.steam-api.com",
+		},
+		{
+			name:   "publisher-only interface",
+			client: Client{PublisherKey: true},
+			interf: "ISteamMicroTxn", method: "InitTxn",
+			want: "https://partner.steam-api.com",
+		},
+		{
+			name:   "custom partner host",
+			client: Client{PublisherKey: true, PartnerBaseURL: "http://partner-proxy"},
+			interf: ISteamUser, method: "CheckAppOwnership",
+			want: "http://partner-proxy",
+		},
+		{
+			name:   "public method with publisher key",
+			client: Client{PublisherKey: true},
+			interf: ISteamUser, method: GetFriendListEndpoint,
+			want: "https://api.steampowered.com",
+		},
+		{
+			name:   "publisher-only method without publisher key",
+			interf: ISteamUserStats, method: SetUserStatsForGameEndpoint,
+			want: "https://api.steampowered.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			assert.Equal(t, tt.want, tt.client.baseURL(ctx, tt.interf, tt.method))
+		})
+	}
+}
+
+func TestClientBaseURLServer(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		fmt.Fprint(w, `{"appnews":{"appid":440}}`)
+	}))
+	defer server.Close()
+
+	client := New("test-key", server.Client())
+	client.BaseURL = server.URL + "/"
+
+	got, err := client.GetNewsForApp(GetNewsForAppParams{AppId: 440, Format: config.Json})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(440), got.AppId)
+
+	// the context wins over the client
+	client.BaseURL = "http://127.0.0.1:1"
+	_, err = client.GetNewsForAppCtx(WithBaseURL(context.Background(), server.URL+"/proxy"), GetNewsForAppParams{AppId: 440})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"/ISteamNews/GetNewsForApp/v2", "/proxy/ISteamNews/GetNewsForApp/v2"}, paths)
+}
@@ -0,0 +1,158 @@
+package steamclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+	"github.com/xemkayx/steam-api/pkg/steamid"
+)
+
+// MaxIDsPerRequest is the number of SteamIDs the multi-ID endpoints like GetPlayerSummaries accept per request
+const MaxIDsPerRequest = 100
+
+// default number of chunks that are requested at the same time
+const defaultBatchConcurrency = 4
+
+// Options of the batched methods, e.g. GetPlayerSummariesBatch
+type BatchOptions struct {
+	Concurrency int                 // Number of chunks requested at the same time (default: 4)
+	Format      config.OutputFormat // Format of the requests, which doesn't change the result
+}
+
+// ChunkError is the error of a single chunk of a batched call
+type ChunkError struct {
+	Index int          // Index of the chunk, in the order of the de-duplicated IDs
+	IDs   []steamid.ID // The IDs of the chunk
+	Err   error        // The error the chunk failed with
+}
+
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("chunk %d (%d IDs): %v", e.Index, len(e.IDs), e.Err)
+}
+
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+/*
+BatchError is returned by batched calls if some of their chunks failed.
+
+The results of the successful chunks are returned alongside it. errors.Is and errors.As look into the errors of all
+failed chunks, so e.g. errors.Is(err, ErrRateLimited) reports whether any chunk was rate limited.
+*/
+type BatchError struct {
+	Chunks []*ChunkError // The failed chunks, ordered by their index
+	Total  int           // The number of chunks of the call
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d of %d chunks failed, first error: %v", len(e.Chunks), e.Total, e.Chunks[0])
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Chunks))
+	for i, c := range e.Chunks {
+		errs[i] = c
+	}
+	return errs
+}
+
+// ChunkIDs removes duplicate IDs (keeping the first occurrence) and splits the rest into chunks of at most size IDs
+func ChunkIDs(ids []steamid.ID, size int) [][]steamid.ID {
+	if size <= 0 {
+		size = MaxIDsPerRequest
+	}
+
+	unique := dedupeIDs(ids)
+	chunks := make([][]steamid.ID, 0, (len(unique)+size-1)/size)
+	for len(unique) > 0 {
+		n := min(size, len(unique))
+		chunks = append(chunks, unique[:n:n])
+		unique = unique[n:]
+	}
+	return chunks
+}
+
+func dedupeIDs(ids []steamid.ID) []steamid.ID {
+	seen := make(map[steamid.ID]bool, len(ids))
+	unique := make([]steamid.ID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+/*
+BatchByID calls fetch for chunks of at most MaxIDsPerRequest IDs and merges the results.
+
+Duplicate IDs are requested only once and at most opts.Concurrency chunks are fetched at the same time.
+The results are ordered like the first occurrence of their ID (as returned by idOf) in ids, no matter in which
+order the API returns them; results whose ID wasn't requested are put at the end.
+If chunks fail, the results of the other chunks are returned together with a *BatchError.
+
+This is how the batched methods of Client are built and can be used for other multi-ID endpoints as well.
+*/
+func BatchByID[T any](ctx context.Context, ids []steamid.ID, opts BatchOptions,
+	fetch func(ctx context.Context, ids []steamid.ID) ([]T, error), idOf func(T) steamid.ID) ([]T, error) {
+	chunks := ChunkIDs(ids, MaxIDsPerRequest)
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	results := make([][]T, len(chunks))
+	errs := make([]error, len(chunks))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(i int, chunk []steamid.ID) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			results[i], errs[i] = fetch(ctx, chunk)
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	var merged []T
+	var failed []*ChunkError
+	for i := range chunks {
+		if errs[i] != nil {
+			failed = append(failed, &ChunkError{Index: i, IDs: chunks[i], Err: errs[i]})
+			continue
+		}
+		merged = append(merged, results[i]...)
+	}
+
+	position := make(map[steamid.ID]int, len(ids))
+	for i, id := range dedupeIDs(ids) {
+		position[id] = i
+	}
+	sort.SliceStable(merged, func(a, b int) bool {
+		pa, okA := position[idOf(merged[a])]
+		pb, okB := position[idOf(merged[b])]
+		if !okA || !okB {
+			return okA && !okB
+		}
+		return pa < pb
+	})
+
+	if len(failed) > 0 {
+		return merged, &BatchError{Chunks: failed, Total: len(chunks)}
+	}
+	return merged, nil
+}
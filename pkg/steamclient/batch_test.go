@@ -0,0 +1,215 @@
+package steamclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	model "github.com/xemkayx/steam-api/pkg/steamclient/model/ISteamUser"
+	"github.com/xemkayx/steam-api/pkg/steamid"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func testIDs(n int) []steamid.ID {
+	ids := make([]steamid.ID, n)
+	for i := range ids {
+		ids[i] = steamid.FromAccountID(uint32(i + 1))
+	}
+	return ids
+}
+
+func TestChunkIDs(t *testing.T) {
+	ids := testIDs(5)
+
+	testCases := []struct {
+		name string
+		ids  []steamid.ID
+		size int
+		want [][]steamid.ID
+	}{
+		{name: "empty", ids: nil, size: 2, want: [][]steamid.ID{}},
+		{name: "exact", ids: ids[:4], size: 2, want: [][]steamid.ID{ids[:2], ids[2:4]}},
+		{name: "remainder", ids: ids, size: 2, want: [][]steamid.ID{ids[:2], ids[2:4], ids[4:]}},
+		{
+			name: "duplicates",
+			ids:  []steamid.ID{ids[0], ids[1], ids[0], ids[2], ids[1]},
+			size: 2,
+			want: [][]steamid.ID{ids[:2], ids[2:3]},
+		},
+		{name: "default size", ids: testIDs(150), size: 0, want: [][]steamid.ID{testIDs(100), testIDs(150)[100:]}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ChunkIDs(tc.ids, tc.size)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ChunkIDs() got = %v , expected %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// registerSummariesResponder answers GetPlayerSummaries with one player per requested ID, in reverse order
+func registerSummariesResponder(t *testing.T, inFlight, maxInFlight, calls *int32, fail func(ids []string) bool) {
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamUser/GetPlayerSummaries/v2",
+		func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(calls, 1)
+			n := atomic.AddInt32(inFlight, 1)
+			defer atomic.AddInt32(inFlight, -1)
+			for {
+				m := atomic.LoadInt32(maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(maxInFlight, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+
+			ids := strings.Split(req.URL.Query().Get("steamids"), ",")
+			if len(ids) > MaxIDsPerRequest {
+				t.Errorf("Requested %d IDs at once", len(ids))
+			}
+			if fail != nil && fail(ids) {
+				return httpmock.NewStringResponse(500, "Internal Server Error"), nil
+			}
+
+			players := make([]string, len(ids))
+			for i, id := range ids {
+				players[len(ids)-1-i] = fmt.Sprintf(`{"steamid":"%s","personaname":"player %s"}`, id, id)
+			}
+			return httpmock.NewStringResponse(200, `{"response":{"players":[`+strings.Join(players, ",")+`]}}`), nil
+		})
+}
+
+func TestGetPlayerSummariesBatch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var inFlight, maxInFlight, calls int32
+	registerSummariesResponder(t, &inFlight, &maxInFlight, &calls, nil)
+
+	ids := testIDs(450)
+	// duplicates are only requested once
+	input := append(append([]steamid.ID{}, ids...), ids[10], ids[300])
+
+	api := New("test-key", &http.Client{})
+	got, err := api.GetPlayerSummariesBatch(context.Background(), input, BatchOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("GetPlayerSummariesBatch() error = %v", err)
+	}
+
+	if calls != 5 {
+		t.Errorf("GetPlayerSummariesBatch() sent %d requests, expected 5", calls)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("GetPlayerSummariesBatch() sent %d requests at once, expected at most 2", maxInFlight)
+	}
+	if len(got) != len(ids) {
+		t.Fatalf("GetPlayerSummariesBatch() returned %d summaries, expected %d", len(got), len(ids))
+	}
+	for i, p := range got {
+		if p.SteamID != ids[i] {
+			t.Fatalf("GetPlayerSummariesBatch() summary %d is %v, expected %v", i, p.SteamID, ids[i])
+		}
+	}
+}
+
+func TestGetPlayerSummariesBatchPartialFailure(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	ids := testIDs(250)
+	failing := ids[100].String()
+
+	var inFlight, maxInFlight, calls int32
+	registerSummariesResponder(t, &inFlight, &maxInFlight, &calls, func(requested []string) bool {
+		return requested[0] == failing
+	})
+
+	api := New("test-key", &http.Client{})
+	got, err := api.GetPlayerSummariesBatch(context.Background(), ids, BatchOptions{})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("GetPlayerSummariesBatch() error = %v, expected a *BatchError", err)
+	}
+	if batchErr.Total != 3 || len(batchErr.Chunks) != 1 || batchErr.Chunks[0].Index != 1 {
+		t.Errorf("GetPlayerSummariesBatch() error = %v, expected chunk 1 of 3 to fail", err)
+	}
+	if !reflect.DeepEqual(batchErr.Chunks[0].IDs, ids[100:200]) {
+		t.Errorf("GetPlayerSummariesBatch() failed chunk has the wrong IDs")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 500 {
+		t.Errorf("GetPlayerSummariesBatch() error = %v, expected to wrap the *APIError", err)
+	}
+
+	want := append(append([]steamid.ID{}, ids[:100]...), ids[200:]...)
+	if len(got) != len(want) {
+		t.Fatalf("GetPlayerSummariesBatch() returned %d summaries, expected %d", len(got), len(want))
+	}
+	for i, p := range got {
+		if p.SteamID != want[i] {
+			t.Fatalf("GetPlayerSummariesBatch() summary %d is %v, expected %v", i, p.SteamID, want[i])
+		}
+	}
+}
+
+func TestGetPlayerBansBatch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamUser/GetPlayerBans/v1",
+		func(req *http.Request) (*http.Response, error) {
+			ids := strings.Split(req.URL.Query().Get("steamids"), ",")
+			players := make([]string, len(ids))
+			for i, id := range ids {
+				players[i] = fmt.Sprintf(`{"SteamId":"%s","VACBanned":true,"NumberOfVACBans":1}`, id)
+			}
+			return httpmock.NewStringResponse(200, `{"players":[`+strings.Join(players, ",")+`]}`), nil
+		})
+
+	ids := testIDs(120)
+	api := New("test-key", &http.Client{})
+	got, err := api.GetPlayerBansBatch(context.Background(), ids, BatchOptions{})
+	if err != nil {
+		t.Fatalf("GetPlayerBansBatch() error = %v", err)
+	}
+	if httpmock.GetTotalCallCount() != 2 {
+		t.Errorf("GetPlayerBansBatch() sent %d requests, expected 2", httpmock.GetTotalCallCount())
+	}
+	if len(got) != len(ids) {
+		t.Fatalf("GetPlayerBansBatch() returned %d bans, expected %d", len(got), len(ids))
+	}
+	for i, b := range got {
+		if b != (model.PlayerBan{SteamID: ids[i], VACBanned: true, NumberOfVACBans: 1}) {
+			t.Fatalf("GetPlayerBansBatch() ban %d = %v", i, b)
+		}
+	}
+}
+
+func TestBatchByIDCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fetch := func(ctx context.Context, ids []steamid.ID) ([]steamid.ID, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return ids, nil
+	}
+	got, err := BatchByID(ctx, testIDs(250), BatchOptions{}, fetch, func(id steamid.ID) steamid.ID { return id })
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("BatchByID() error = %v, expected context.Canceled", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("BatchByID() returned %d results, expected none", len(got))
+	}
+}
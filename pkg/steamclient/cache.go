@@ -0,0 +1,211 @@
+package steamclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+Cache stores the bodies of successful responses. Implementations have to be safe for concurrent use.
+
+Keys are built from the request URL without the API-key, so responses are shared between Clients with different
+keys that use the same Cache. LRUCache and FileCache are provided by this package.
+*/
+type Cache interface {
+	// Get returns the value stored under key, unless it's missing or expired
+	Get(key string) ([]byte, bool)
+	// Set stores value under key for the duration of ttl
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+/*
+DefaultCacheTTLs are the times responses of the endpoints are cached for, keyed by "Interface/Endpoint".
+Endpoints that are not listed are not cached. Client.CacheTTLs overrides single entries.
+*/
+var DefaultCacheTTLs = map[string]time.Duration{
+	ISteamNews + "/" + GetNewsForAppEndpoint: 15 * time.Minute,
+
+	ISteamUser + "/" + GetPlayerSummariesEndpoint: time.Minute,
+	ISteamUser + "/" + GetFriendListEndpoint:      5 * time.Minute,
+	ISteamUser + "/" + GetPlayerBansEndpoint:      time.Hour,
+	ISteamUser + "/" + ResolveVanityURLEndpoint:   24 * time.Hour,
+
+	ISteamUserStats + "/" + GetGlobalAchievementPercentagesForAppEndpoint: time.Hour,
+	ISteamUserStats + "/" + GetGlobalStatsForGameEndpoint:                 time.Hour,
+	ISteamUserStats + "/" + GetNumberOfCurrentPlayersEndpoint:             time.Minute,
+	ISteamUserStats + "/" + GetPlayerAchievementsEndpoint:                 5 * time.Minute,
+	ISteamUserStats + "/" + GetSchemaForGameEndpoint:                      24 * time.Hour,
+	ISteamUserStats + "/" + GetUserStatsForGameEndpoint:                   5 * time.Minute,
+
+	IPlayerService + "/" + GetOwnedGamesEndpoint:             10 * time.Minute,
+	IPlayerService + "/" + GetRecentlyPlayedGamesEndpoint:    10 * time.Minute,
+	IPlayerService + "/" + GetSteamLevelEndpoint:             10 * time.Minute,
+	IPlayerService + "/" + GetBadgesEndpoint:                 10 * time.Minute,
+	IPlayerService + "/" + GetCommunityBadgeProgressEndpoint: 10 * time.Minute,
+
+	ISteamApps + "/" + GetAppListEndpoint:          time.Hour,
+	ISteamApps + "/" + GetServersAtAddressEndpoint: time.Minute,
+	ISteamApps + "/" + UpToDateCheckEndpoint:       5 * time.Minute,
+
+	ISteamWebAPIUtil + "/" + GetSupportedAPIListEndpoint: time.Hour,
+}
+
+type cacheTTLKey struct{}
+
+// WithCacheTTL returns a context that caches the responses of requests sent with it for ttl,
+// instead of the default of the endpoint. A ttl of 0 or less bypasses the cache.
+func WithCacheTTL(ctx context.Context, ttl time.Duration) context.Context {
+	return context.WithValue(ctx, cacheTTLKey{}, ttl)
+}
+
+// cacheTTL returns how long the response of the given endpoint is cached for
+func (c Client) cacheTTL(ctx context.Context, interf, endpoint string) time.Duration {
+	if ttl, ok := ctx.Value(cacheTTLKey{}).(time.Duration); ok {
+		return ttl
+	}
+	name := interf + "/" + endpoint
+	if ttl, ok := c.CacheTTLs[name]; ok {
+		return ttl
+	}
+	return DefaultCacheTTLs[name]
+}
+
+// cacheKey normalizes the request URL and removes the API-key from it
+func cacheKey(urlStr string) string {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return urlStr
+	}
+	query := u.Query()
+	query.Del("key")
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.RawQuery = query.Encode()
+	u.Fragment = ""
+	return u.String()
+}
+
+// cachedGet returns the body of the response of fetch from c.Cache, or fetches it and caches it if cacheable is nil
+// or accepts it.
+// Concurrent calls of c with the same key share a single request, see flightGroup.
+func (c Client) cachedGet(ctx context.Context, urlStr string, ttl time.Duration, fetch func(context.Context) (*http.Response, error), cacheable func(body []byte) bool) (*http.Response, error) {
+	key := cacheKey(urlStr)
+	if body, ok := c.Cache.Get(key); ok {
+		return cachedResponse(body), nil
+	}
+
+	fetchBody := func(ctx context.Context) ([]byte, error) {
+		resp, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if cacheable == nil || cacheable(body) {
+			c.Cache.Set(key, body, ttl)
+		}
+		return body, nil
+	}
+
+	var body []byte
+	var err error
+	if c.flights == nil {
+		// a Client that wasn't created by a constructor has no flightGroup
+		body, err = fetchBody(ctx)
+	} else {
+		// the cache key has no API-key, the request does
+		body, err = c.flights.do(ctx, c.Key+" "+key, fetchBody)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cachedResponse(body), nil
+}
+
+func cachedResponse(body []byte) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+}
+
+// flightGroup coalesces concurrent calls with the same key into one. Every Client created by a constructor has
+// its own, so only requests sent with the same transport, middleware and cache are merged.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	waiters int                // callers still waiting for the call, guarded by flightGroup.mu
+	cancel  context.CancelFunc // cancels the call once no caller waits anymore
+}
+
+/*
+do calls fn once for all concurrent callers with the same key and hands its result to all of them.
+
+fn runs with a context that keeps the values of the first caller's ctx but isn't canceled with it. Callers whose
+ctx ends stop waiting with its error, while the call keeps running for the others; it's only canceled once all
+callers have stopped waiting.
+*/
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*flightCall{}
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+
+		go func() {
+			defer cancel()
+			body, err := fn(callCtx)
+
+			g.mu.Lock()
+			call.body, call.err = body, err
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.body, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// nobody waits for the result anymore; a later caller starts a new call
+			call.cancel()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,75 @@
+package steamclient
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+/*
+FileCache is a Cache that stores every response in its own file, so it survives restarts of the program.
+
+The files are named after the SHA-256 of their key and start with the expiry time. Expired files are removed
+when they are read.
+*/
+type FileCache struct {
+	dir string
+	now func() time.Time
+}
+
+// NewFileCache creates a FileCache in dir, creating the directory if needed
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir, now: time.Now}, nil
+}
+
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) < 8 {
+		return nil, false
+	}
+
+	expires := time.Unix(0, int64(binary.BigEndian.Uint64(data[:8])))
+	if !c.now().Before(expires) {
+		os.Remove(path)
+		return nil, false
+	}
+	return data[8:], true
+}
+
+func (c *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	data := make([]byte, 8+len(value))
+	binary.BigEndian.PutUint64(data, uint64(c.now().Add(ttl).UnixNano()))
+	copy(data[8:], value)
+
+	// write to a temporary file first, so readers never see half a response
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,89 @@
+package steamclient
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// default number of entries of an LRUCache
+const defaultLRUCacheSize = 1024
+
+// LRUCache is an in-memory Cache that evicts the least recently used entry when it's full
+type LRUCache struct {
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // front is the most recently used entry
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRUCache creates an LRUCache holding up to maxEntries responses (default: 1024)
+func NewLRUCache(maxEntries int) *LRUCache {
+	if maxEntries <= 0 {
+		maxEntries = defaultLRUCacheSize
+	}
+	return &LRUCache{
+		maxEntries: maxEntries,
+		now:        time.Now,
+		order:      list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*lruEntry)
+	if !c.now().Before(entry.expires) {
+		c.remove(el)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(ttl)
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*lruEntry)
+		entry.value, entry.expires = value, expires
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+// Len returns the number of entries, including expired ones that were not removed yet
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRUCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*lruEntry).key)
+}
@@ -0,0 +1,359 @@
+package steamclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+	"github.com/xemkayx/steam-api/pkg/steamid"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheKey(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{
+			url:  "https://api.steampowered.com/ISteamNews/GetNewsForApp/v2?key=secret&appid=440&format=json",
+			want: "https://api.steampowered.com/ISteamNews/GetNewsForApp/v2?appid=440&format=json",
+		},
+		{
+			url:  "HTTPS://API.steampowered.com/ISteamNews/GetNewsForApp/v2?format=json&appid=440#top",
+			want: "https://api.steampowered.com/ISteamNews/GetNewsForApp/v2?appid=440&format=json",
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, cacheKey(tt.url))
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	c := NewLRUCache(2)
+	c.now = clock.Now
+
+	c.Set("a", []byte("1"), time.Minute)
+	c.Set("b", []byte("2"), time.Minute)
+	_, _ = c.Get("a") // a is now the most recently used entry
+	c.Set("c", []byte("3"), time.Minute)
+
+	_, ok := c.Get("b")
+	assert.False(t, ok, "b should have been evicted")
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), v)
+	assert.Equal(t, 2, c.Len())
+
+	c.Set("c", []byte("4"), 2*time.Minute)
+	clock.Advance(time.Minute)
+	_, ok = c.Get("a")
+	assert.False(t, ok, "a should have expired")
+	v, ok = c.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("4"), v)
+
+	c.Set("d", []byte("5"), 0)
+	_, ok = c.Get("d")
+	assert.False(t, ok, "values without ttl should not be stored")
+}
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	c, err := NewFileCache(dir)
+	assert.NoError(t, err)
+	c.now = clock.Now
+
+	c.Set("https://api.steampowered.com/a", []byte(`{"a":1}`), time.Minute)
+	v, ok := c.Get("https://api.steampowered.com/a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte(`{"a":1}`), v)
+
+	// a new cache in the same directory sees the entry
+	c2, err := NewFileCache(dir)
+	assert.NoError(t, err)
+	c2.now = clock.Now
+	_, ok = c2.Get("https://api.steampowered.com/a")
+	assert.True(t, ok)
+
+	_, ok = c.Get("https://api.steampowered.com/b")
+	assert.False(t, ok)
+
+	clock.Advance(time.Minute)
+	_, ok = c.Get("https://api.steampowered.com/a")
+	assert.False(t, ok, "the entry should have expired")
+}
+
+func TestClientCache(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamNews/GetNewsForApp/v2",
+		httpmock.NewStringResponder(200, `{"appnews":{"appid":440}}`))
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamUser/GetFriendList/v1",
+		httpmock.NewStringResponder(503, "Service Unavailable"))
+
+	cache := NewLRUCache(0)
+	client := New("test-key", &http.Client{})
+	client.Cache = cache
+	params := GetNewsForAppParams{AppId: 440, Format: config.Json}
+
+	for i := 0; i < 3; i++ {
+		got, err := client.GetNewsForApp(params)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(440), got.AppId)
+	}
+	assert.Equal(t, 1, httpmock.GetTotalCallCount(), "the responses should be cached")
+
+	// clients with a different key share the cache
+	other := New("other-key", &http.Client{})
+	other.Cache = cache
+	_, err := other.GetNewsForApp(params)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+
+	// a ttl of 0 bypasses the cache
+	_, err = client.GetNewsForAppCtx(WithCacheTTL(context.Background(), 0), params)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+
+	// other formats are cached separately
+	_, err = client.GetNewsForApp(GetNewsForAppParams{AppId: 440, Format: config.Vdf})
+	assert.Error(t, err, "the JSON response can't be decoded as VDF")
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+
+	// errors are not cached
+	for i := 0; i < 2; i++ {
+		_, err = client.GetFriendList(GetFriendListParams{SteamId: 76561197960435530})
+		assert.ErrorAs(t, err, new(*APIError))
+	}
+	assert.Equal(t, 5, httpmock.GetTotalCallCount())
+	assert.Equal(t, 2, cache.Len(), "only the JSON and VDF responses should be cached")
+}
+
+func TestClientCacheSkipsNoMatch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// the vanity URL is claimed after the first request
+	responses := []string{
+		`{"response":{"success":42,"message":"No match"}}`,
+		`{"response":{"steamid":"76561197960435530","success":1}}`,
+	}
+	calls := 0
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamUser/ResolveVanityURL/v1",
+		func(req *http.Request) (*http.Response, error) {
+			body := responses[min(calls, len(responses)-1)]
+			calls++
+			return httpmock.NewStringResponse(200, body), nil
+		})
+
+	client := New("test-key", &http.Client{})
+	client.Cache = NewLRUCache(0)
+	params := ResolveVanityURLParams{VanityURL: "gabelogannewell"}
+
+	_, err := client.ResolveVanityURL(params)
+	assert.ErrorIs(t, err, ErrNoMatch)
+
+	for i := 0; i < 2; i++ {
+		got, err := client.ResolveVanityURL(params)
+		assert.NoError(t, err)
+		assert.Equal(t, steamid.ID(76561197960435530), got.SteamID)
+	}
+	assert.Equal(t, 2, httpmock.GetTotalCallCount(), "only the match should be cached")
+}
+
+func TestClientCacheSkipsFailedResults(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	responses := []string{
+		`{"response":{"result":8,"error":"Missing or invalid required parameter: name[0]"}}`,
+		`{"response":{"globalstats":{"global.map.emp_isle":{"total":"4292527"}},"result":1}}`,
+	}
+	calls := 0
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamUserStats/GetGlobalStatsForGame/v1",
+		func(req *http.Request) (*http.Response, error) {
+			body := responses[min(calls, len(responses)-1)]
+			calls++
+			return httpmock.NewStringResponse(200, body), nil
+		})
+
+	client := NewClientWithoutKey(&http.Client{})
+	client.Cache = NewLRUCache(0)
+	params := GlobalGameStatsParams{AppID: 17740, Names: []string{"global.map.emp_isle"}}
+
+	_, err := client.GetGlobalStatsForGame(params)
+	assert.ErrorIs(t, err, ErrResultFailed)
+
+	for i := 0; i < 2; i++ {
+		got, err := client.GetGlobalStatsForGame(params)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4292527), got.GlobalStats["global.map.emp_isle"].Total)
+	}
+	assert.Equal(t, 2, httpmock.GetTotalCallCount(), "only the successful result should be cached")
+}
+
+func TestClientCacheTTLs(t *testing.T) {
+	client := Client{CacheTTLs: map[string]time.Duration{"ISteamNews/GetNewsForApp": time.Second}}
+
+	assert.Equal(t, time.Second, client.cacheTTL(context.Background(), ISteamNews, GetNewsForAppEndpoint))
+	assert.Equal(t, 24*time.Hour, client.cacheTTL(context.Background(), ISteamUserStats, GetSchemaForGameEndpoint))
+	assert.Equal(t, time.Duration(0), client.cacheTTL(context.Background(), "IUnknown", "Unknown"))
+
+	ctx := WithCacheTTL(context.Background(), time.Hour)
+	assert.Equal(t, time.Hour, client.cacheTTL(ctx, ISteamNews, GetNewsForAppEndpoint))
+}
+
+func TestClientCacheCoalescesRequests(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var calls int32
+	release := make(chan struct{})
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamNews/GetNewsForApp/v2",
+		func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return httpmock.NewStringResponse(200, `{"appnews":{"appid":440}}`), nil
+		})
+
+	client := New("test-key", &http.Client{})
+	client.Cache = NewLRUCache(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := client.GetNewsForApp(GetNewsForAppParams{AppId: 440, Format: config.Json})
+			if assert.NoError(t, err) {
+				assert.Equal(t, int64(440), got.AppId)
+			}
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestClientCacheCoalescedLeaderCanceled(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var calls int32
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamNews/GetNewsForApp/v2",
+		func(req *http.Request) (*http.Response, error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				close(started)
+			}
+			select {
+			case <-release:
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			}
+			return httpmock.NewStringResponse(200, `{"appnews":{"appid":440}}`), nil
+		})
+
+	client := New("test-key", &http.Client{})
+	client.Cache = NewLRUCache(0)
+	params := GetNewsForAppParams{AppId: 440, Format: config.Json}
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.GetNewsForAppCtx(leaderCtx, params)
+		leaderErr <- err
+	}()
+	<-started
+
+	followerErr := make(chan error, 1)
+	go func() {
+		got, err := client.GetNewsForAppCtx(context.Background(), params)
+		if err == nil {
+			assert.Equal(t, int64(440), got.AppId)
+		}
+		followerErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancelLeader()
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+
+	close(release)
+	assert.NoError(t, <-followerErr, "the follower's context is still live")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestClientCacheDoesNotCoalesceClients(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var mu sync.Mutex
+	keys := map[string]int{}
+	release := make(chan struct{})
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamNews/GetNewsForApp/v2",
+		func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			keys[req.URL.Query().Get("key")]++
+			mu.Unlock()
+			<-release
+			return httpmock.NewStringResponse(200, `{"appnews":{"appid":440}}`), nil
+		})
+
+	cache := NewLRUCache(0)
+	var wg sync.WaitGroup
+	for _, key := range []string{"key-a", "key-b"} {
+		client := New(key, &http.Client{})
+		client.Cache = cache
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetNewsForApp(GetNewsForAppParams{AppId: 440, Format: config.Json})
+			assert.NoError(t, err)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, map[string]int{"key-a": 1, "key-b": 1}, keys)
+}
+
+func TestFlightGroupCancelsAbandonedCall(t *testing.T) {
+	var g flightGroup
+	ctx, cancel := context.WithCancel(context.Background())
+	fnDone := make(chan error, 1)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	_, err := g.do(ctx, "key", func(ctx context.Context) ([]byte, error) {
+		<-ctx.Done()
+		fnDone <- ctx.Err()
+		return nil, ctx.Err()
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	select {
+	case err := <-fnDone:
+		assert.ErrorIs(t, err, context.Canceled, "the call is canceled once nobody waits for it")
+	case <-time.After(time.Second):
+		t.Fatal("the abandoned call was not canceled")
+	}
+}
@@ -0,0 +1,288 @@
+package steamclient
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+	"github.com/xemkayx/steam-api/pkg/vdf"
+)
+
+// CallOption configures a request sent with Client.Call
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	method    string
+	format    config.OutputFormat
+	inputJSON bool
+}
+
+// CallWithMethod sets the HTTP method of the request, http.MethodGet (default) or http.MethodPost
+func CallWithMethod(method string) CallOption {
+	return func(o *callOptions) { o.method = method }
+}
+
+// CallWithFormat sets the format Steam answers in (default: config.Json). out is decoded accordingly.
+func CallWithFormat(format config.OutputFormat) CallOption {
+	return func(o *callOptions) { o.format = format }
+}
+
+// CallWithInputJSON sends the params as JSON in the input_json parameter, like the service interfaces
+// (IPlayerService & co.) expect for nested or list parameters
+func CallWithInputJSON() CallOption {
+	return func(o *callOptions) { o.inputJSON = true }
+}
+
+/*
+Call sends a request to any method of the Web API and decodes the answer into out.
+It's meant for methods this package has no typed support for yet:
+
+	var out struct {
+		Level int `json:"player_level"`
+	}
+	params := struct {
+		SteamID steamid.ID `steam:"steamid"`
+	}{SteamID: 76561197960435530}
+	err := client.Call(ctx, "IPlayerService", "GetSteamLevel", 1, params, &out)
+
+params can be a struct, a map with string keys, url.Values or nil. The names of struct fields are taken from their
+`steam` tag, falling back to the `json` tag and the lower-cased field name; the omitempty option skips zero values.
+Lists are sent as name[0], name[1], ... and values implementing encoding.TextMarshaler (like steamid.ID) as their
+text. With CallWithInputJSON, params are sent as JSON in the input_json parameter instead, which also allows nested
+structs. The API-key of the Client is added, if it has one.
+
+out can be anything the format's decoder accepts, or nil to discard the answer. The "response" object most methods
+wrap their answer in is unwrapped, so out only has to describe its content.
+
+GET requests go through the retry policy and cache of the Client like the typed methods, POST requests
+(see CallWithMethod) are sent exactly once. For parameters Call can't express, like query parameters of POST
+requests or input_protobuf_encoded, build a Request and send it with Client.Do.
+*/
+func (c Client) Call(ctx context.Context, iface, method string, version int, params any, out any, opts ...CallOption) error {
+	o := callOptions{method: http.MethodGet, format: config.Json}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	vals, err := callValues(params, o.inputJSON)
+	if err != nil {
+		return err
+	}
+	req := NewRequest(iface, method, version).Method(o.method).Format(o.format).SetValues(vals)
+	return c.Do(ctx, req, out)
+}
+
+// callValues encodes params into request parameters
+func callValues(params any, inputJSON bool) (url.Values, error) {
+	if inputJSON {
+		vals := url.Values{}
+		if params == nil {
+			return vals, nil
+		}
+		m, err := paramsMap(params)
+		if err != nil {
+			return nil, err
+		}
+		jsonBytes, err := json.Marshal(m)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling JSON: %w", err)
+		}
+		vals.Set("input_json", string(jsonBytes))
+		return vals, nil
+	}
+
+	if v, ok := params.(url.Values); ok {
+		vals := url.Values{}
+		for k, values := range v {
+			vals[k] = append([]string(nil), values...)
+		}
+		return vals, nil
+	}
+
+	vals := url.Values{}
+	if params == nil {
+		return vals, nil
+	}
+	m, err := paramsMap(params)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range m {
+		if err := addQueryValue(vals, name, reflect.ValueOf(value)); err != nil {
+			return nil, err
+		}
+	}
+	return vals, nil
+}
+
+// paramsMap converts a struct or map into a map of parameter names to values
+func paramsMap(params any) (map[string]any, error) {
+	v := reflect.ValueOf(params)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return map[string]any{}, nil
+		}
+		v = v.Elem()
+	}
+
+	m := map[string]any{}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("the keys of params have to be strings, not %v", v.Type().Key())
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = iter.Value().Interface()
+		}
+	case reflect.Struct:
+		addStructParams(m, v)
+	default:
+		return nil, fmt.Errorf("params have to be a struct or a map, not %v", v.Type())
+	}
+	return m, nil
+}
+
+func addStructParams(m map[string]any, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+
+		tag, hasTag := sf.Tag.Lookup("steam")
+		if !hasTag {
+			tag, hasTag = sf.Tag.Lookup("json")
+		}
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if sf.Anonymous && (!hasTag || name == "") {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				addStructParams(m, fv)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if opts == "omitempty" && fv.IsZero() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		m[name] = fv.Interface()
+	}
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// addQueryValue adds v to vals; lists are added as name[0], name[1], ...
+func addQueryValue(vals url.Values, name string, v reflect.Value) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return fmt.Errorf("parameter %s: %w", name, err)
+		}
+		vals.Set(name, string(text))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		vals.Set(name, v.String())
+	case reflect.Bool:
+		vals.Set(name, strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		vals.Set(name, strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		vals.Set(name, strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		vals.Set(name, strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()))
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := addQueryValue(vals, fmt.Sprintf("%s[%d]", name, i), v.Index(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("parameter %s: %v can't be sent as a query parameter, use CallWithInputJSON", name, v.Type())
+	}
+	return nil
+}
+
+// decodeUnwrapped decodes body into out, unwrapping the "response" object most methods answer with.
+// Protobuf is decoded into out by its UnmarshalProto method, or kept as it is if out is a *[]byte.
+func decodeUnwrapped(format config.OutputFormat, body io.Reader, out any) error {
+	switch format {
+	case config.Json:
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		var envelope map[string]json.RawMessage
+		if json.Unmarshal(data, &envelope) == nil && len(envelope) == 1 {
+			if inner, ok := envelope["response"]; ok {
+				data = inner
+			}
+		}
+		return json.NewDecoder(bytes.NewReader(data)).Decode(out)
+
+	case config.Xml:
+		// the name of the root element is not checked, so <response> is unwrapped already
+		return xml.NewDecoder(body).Decode(out)
+
+	case config.Vdf:
+		root, err := vdf.Parse(body)
+		if err != nil {
+			return err
+		}
+		if len(root.Children) == 1 && strings.EqualFold(root.Children[0].Key, "response") && root.Children[0].IsObject() {
+			root = root.Children[0]
+		}
+		return root.Decode(out)
+
+	case config.Protobuf:
+		switch out := out.(type) {
+		case protoUnmarshaler:
+			return decodeProtobuf(out, body)
+		case *[]byte:
+			b, err := io.ReadAll(body)
+			*out = b
+			return err
+		default:
+			return fmt.Errorf("%T can't be decoded from protobuf, it needs an UnmarshalProto method", out)
+		}
+
+	default:
+		return unsupportedFormatError(format)
+	}
+}
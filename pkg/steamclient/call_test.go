@@ -0,0 +1,239 @@
+package steamclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+	"github.com/xemkayx/steam-api/pkg/steamid"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallValues(t *testing.T) {
+	type embedded struct {
+		Language string `steam:"l,omitempty"`
+	}
+	type params struct {
+		embedded
+		SteamID  steamid.ID   `steam:"steamid"`
+		AppIDs   []uint32     `steam:"appids"`
+		Count    *int         `steam:"count"`
+		Filter   string       `json:"filter,omitempty"`
+		Include  bool         `steam:"include_appinfo"`
+		Ratio    float64      `steam:"ratio,omitempty"`
+		Friends  []steamid.ID `steam:"friends,omitempty"`
+		Name     string
+		internal string
+		Skipped  string `steam:"-"`
+	}
+
+	count := 5
+	tests := []struct {
+		name      string
+		params    any
+		inputJSON bool
+		want      url.Values
+		wantErr   bool
+	}{
+		{
+			name:   "nil",
+			params: nil,
+			want:   url.Values{},
+		},
+		{
+			name: "struct",
+			params: params{
+				embedded: embedded{Language: "german"},
+				SteamID:  76561197960435530,
+				AppIDs:   []uint32{440, 570},
+				Count:    &count,
+				Include:  true,
+				Name:     "x",
+				internal: "y",
+				Skipped:  "z",
+			},
+			want: url.Values{
+				"l":               {"german"},
+				"steamid":         {"76561197960435530"},
+				"appids[0]":       {"440"},
+				"appids[1]":       {"570"},
+				"count":           {"5"},
+				"include_appinfo": {"true"},
+				"name":            {"x"},
+			},
+		},
+		{
+			name:   "pointer to struct",
+			params: &params{SteamID: 76561197960435530},
+			want: url.Values{
+				"steamid":         {"76561197960435530"},
+				"include_appinfo": {"false"},
+				"name":            {""},
+			},
+		},
+		{
+			name:   "map",
+			params: map[string]any{"appid": 440, "steamids": []steamid.ID{1, 2}},
+			want:   url.Values{"appid": {"440"}, "steamids[0]": {"1"}, "steamids[1]": {"2"}},
+		},
+		{
+			name:   "url.Values",
+			params: url.Values{"appid": {"440"}},
+			want:   url.Values{"appid": {"440"}},
+		},
+		{
+			name: "input_json",
+			params: struct {
+				SteamID steamid.ID `steam:"steamid"`
+				AppIDs  []uint32   `json:"appids_filter"`
+				Nested  struct {
+					A int `json:"a"`
+				} `steam:"nested"`
+			}{SteamID: 76561197960435530, AppIDs: []uint32{440}},
+			inputJSON: true,
+			want:      url.Values{"input_json": {`{"appids_filter":[440],"nested":{"a":0},"steamid":"76561197960435530"}`}},
+		},
+		{
+			name:    "nested struct in query",
+			params:  map[string]any{"nested": struct{ A int }{}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid params",
+			params:  42,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callValues(tt.params, tt.inputJSON)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCall(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	type level struct {
+		PlayerLevel int `json:"player_level" xml:"player_level"`
+	}
+
+	tests := []struct {
+		name     string
+		format   config.OutputFormat
+		response string
+	}{
+		{name: "JSON", format: config.Json, response: `{"response":{"player_level":42}}`},
+		{name: "JSON without envelope", format: config.Json, response: `{"player_level":42}`},
+		{name: "XML", format: config.Xml, response: `<?xml version="1.0" encoding="UTF-8"?>
+			<!DOCTYPE response>
+			<response><player_level>42</player_level></response>`},
+		{name: "VDF", format: config.Vdf, response: `"response"
+			{
+				"player_level"	"42"
+			}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/GetSteamLevel/v1",
+				func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, url.Values{
+						"key":     {"test-key"},
+						"steamid": {"76561197960435530"},
+						"format":  {tt.format.String()},
+					}, req.URL.Query())
+					return httpmock.NewStringResponse(200, tt.response), nil
+				})
+
+			client := New("test-key", &http.Client{})
+			params := struct {
+				SteamID steamid.ID `steam:"steamid"`
+			}{SteamID: 76561197960435530}
+
+			var out level
+			err := client.Call(context.Background(), "IPlayerService", "GetSteamLevel", 1, params, &out, CallWithFormat(tt.format))
+			assert.NoError(t, err)
+			assert.Equal(t, 42, out.PlayerLevel)
+		})
+	}
+}
+
+func TestCallPost(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "https://api.steampowered.com/ISteamUserStats/SetUserStatsForGame/v1",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Empty(t, req.URL.RawQuery, "POST parameters belong into the body")
+			assert.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+			body, _ := io.ReadAll(req.Body)
+			form, _ := url.ParseQuery(string(body))
+			assert.Equal(t, url.Values{
+				"key":      {"test-key"},
+				"format":   {"json"},
+				"appid":    {"440"},
+				"steamid":  {"76561197960435530"},
+				"count":    {"1"},
+				"name[0]":  {"kills"},
+				"value[0]": {"10"},
+			}, form)
+			return httpmock.NewStringResponse(503, ""), nil
+		})
+
+	client := New("test-key", &http.Client{})
+	client.Retry = &RetryPolicy{MaxAttempts: 3}
+	params := map[string]any{
+		"appid":   440,
+		"steamid": steamid.ID(76561197960435530),
+		"count":   1,
+		"name":    []string{"kills"},
+		"value":   []int{10},
+	}
+
+	err := client.Call(context.Background(), ISteamUserStats, SetUserStatsForGameEndpoint, 1, params, nil, CallWithMethod(http.MethodPost))
+	assert.ErrorAs(t, err, new(*APIError))
+	assert.Equal(t, 1, httpmock.GetTotalCallCount(), "POST requests must not be retried")
+}
+
+func TestCallOptions(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/GetOwnedGames/v1",
+		func(req *http.Request) (*http.Response, error) {
+			var input map[string]any
+			assert.NoError(t, json.Unmarshal([]byte(req.URL.Query().Get("input_json")), &input))
+			assert.Equal(t, map[string]any{"steamid": "76561197960435530", "appids_filter": []any{440.0}}, input)
+			assert.Empty(t, req.URL.Query().Get("key"), "clients without key should not send one")
+			return httpmock.NewStringResponse(200, `{"response":{"game_count":1}}`), nil
+		})
+
+	client := NewClientWithoutKey(&http.Client{})
+	params := map[string]any{"steamid": steamid.ID(76561197960435530), "appids_filter": []int{440}}
+
+	var out json.RawMessage
+	err := client.Call(context.Background(), IPlayerService, GetOwnedGamesEndpoint, 1, params, &out, CallWithInputJSON())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"game_count":1}`, string(out))
+
+	err = client.Call(context.Background(), IPlayerService, GetOwnedGamesEndpoint, 1, nil, &out, CallWithMethod(http.MethodDelete))
+	assert.Error(t, err)
+	err = client.Call(context.Background(), IPlayerService, GetOwnedGamesEndpoint, 1, nil, &out, CallWithFormat(config.OutputFormat(42)))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
@@ -6,6 +6,7 @@ const (
 	Json = iota
 	Xml
 	Vdf
+	Protobuf // Binary protobuf, only answered by the service interfaces (I*Service)
 )
 
 func (o OutputFormat) String() string {
@@ -16,6 +17,8 @@ func (o OutputFormat) String() string {
 		return "xml"
 	case Vdf:
 		return "vdf"
+	case Protobuf:
+		return "protobuf_raw"
 	default:
 		return "unknown format"
 	}
@@ -87,3 +90,26 @@ func (l Language) String() string {
 		return "english"
 	}
 }
+
+// -------------------------------------
+
+type VanityURLType int
+
+const (
+	Individual = iota + 1
+	Group
+	GameGroup
+)
+
+func (v VanityURLType) String() string {
+	switch v {
+	case Individual:
+		return "1"
+	case Group:
+		return "2"
+	case GameGroup:
+		return "3"
+	default:
+		return "1"
+	}
+}
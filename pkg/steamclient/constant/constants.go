@@ -1,5 +1,6 @@
 package constant
 
 const (
-	SteamWebApiBaseURL = "https://api.steampowered.com"
+	SteamWebApiBaseURL     = "https://api.steampowered.com"
+	SteamPartnerApiBaseURL = "https://partner.steam-api.com" // host for publisher-only methods, which need a publisher key
 )
@@ -0,0 +1,151 @@
+package steamclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	urlHelper "github.com/xemkayx/steam-api/internal/urlHelper"
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+)
+
+// maximum number of bytes of a failed response body that is kept in an APIError
+const maxErrorBodySize = 512
+
+// Sentinel errors returned by the endpoint methods. Use errors.Is to check for them.
+var (
+	ErrKeyRequired       = errors.New(apiKeyErrorMessage)                                               // the endpoint needs an API-key, but the Client has none
+	ErrTooManyIDs        = errors.New("you have provided too many Steam IDs. reduce the amount to 100") // more IDs were passed than the endpoint accepts
+	ErrRateLimited       = errors.New("the Steam API rate limit was exceeded")                          // Steam answered with 429 Too Many Requests
+	ErrPrivateProfile    = errors.New("the requested profile is not public")                            // the requested data is hidden by the user's privacy settings
+	ErrUnsupportedFormat = errors.New("unsupported format requested")                                   // the requested config.OutputFormat can't be decoded
+	ErrNoMatch           = errors.New("no match found")                                                 // a vanity URL or profile could not be resolved
+	ErrPublisherKey      = errors.New("this endpoint needs a publisher key")                            // a publisher-only endpoint was called without Client.PublisherKey
+	ErrInvalidStat       = errors.New("invalid stat")                                                   // a stat is not in the game's schema or has an invalid value
+	ErrResultFailed      = errors.New("steam reported a failed result")                                 // Steam answered with 200, but a result other than 1
+)
+
+/*
+APIError is returned when the Steam API answers with a status code other than 200.
+
+It unwraps to ErrRateLimited or ErrPrivateProfile where the status code allows it,
+so both errors.Is and errors.As can be used to inspect it.
+*/
+type APIError struct {
+	StatusCode int           // HTTP status code of the response
+	Interface  string        // Interface of the called endpoint, e.g. ISteamUser
+	Endpoint   string        // Name of the called endpoint, e.g. GetPlayerSummaries
+	Version    string        // Version of the called endpoint
+	Body       string        // The beginning of the response body
+	RetryAfter time.Duration // Value of the Retry-After header, if Steam sent one
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s/%s/v%s: status code was %d", e.Interface, e.Endpoint, e.Version, e.StatusCode)
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error matching the status code, if there is one
+func (e *APIError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusUnauthorized:
+		// GetFriendList & co. answer with 401 if the profile is private
+		return ErrPrivateProfile
+	case http.StatusForbidden:
+		// 403 is also used for invalid keys, only the body tells them apart
+		if strings.Contains(strings.ToLower(e.Body), "not public") {
+			return ErrPrivateProfile
+		}
+	}
+	return nil
+}
+
+/*
+ResultError is returned when Steam answers with 200, but the result field of the response tells that the request
+failed. It unwraps to ErrResultFailed.
+*/
+type ResultError struct {
+	Interface string // Interface of the called endpoint, e.g. ISteamUserStats
+	Endpoint  string // Name of the called endpoint, e.g. GetGlobalStatsForGame
+	Result    int    // The result Steam answered with, 1 would be success
+	Message   string // The error message Steam sent along, if any
+}
+
+func (e *ResultError) Error() string {
+	msg := fmt.Sprintf("%s/%s: result was %d", e.Interface, e.Endpoint, e.Result)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Unwrap returns ErrResultFailed
+func (e *ResultError) Unwrap() error {
+	return ErrResultFailed
+}
+
+// checkResponse returns an *APIError for every response whose status code is not 200.
+// The body of such a response is consumed and closed.
+func checkResponse(resp *http.Response, interf string, endpoint urlHelper.VersionedURLEndpoint) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Interface:  interf,
+		Endpoint:   endpoint.EndpointPath,
+		Version:    endpoint.Version,
+		Body:       strings.TrimSpace(string(body)),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+}
+
+// parseRetryAfter parses the seconds or HTTP date of a Retry-After header. It returns 0 for missing or invalid values.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(0, time.Until(date))
+	}
+	return 0
+}
+
+// unsupportedFormatError wraps ErrUnsupportedFormat with the requested format
+func unsupportedFormatError(format config.OutputFormat) error {
+	return fmt.Errorf("%w: %v", ErrUnsupportedFormat, format)
+}
+
+// checkTextFormat returns an error wrapping ErrUnsupportedFormat for formats other than JSON, XML and VDF. Methods
+// of the service interfaces without a protobuf model call it before sending, checkFormat lets protobuf_raw pass there.
+func checkTextFormat(format config.OutputFormat) error {
+	switch format {
+	case config.Json, config.Xml, config.Vdf:
+		return nil
+	}
+	return unsupportedFormatError(format)
+}
+
+// checkFormat returns an error wrapping ErrUnsupportedFormat if protobuf_raw is requested from an interface other
+// than the service interfaces, the only ones answering it. It's checked before a request is sent.
+func checkFormat(interf, format string) error {
+	if format == config.OutputFormat(config.Protobuf).String() && !strings.HasSuffix(interf, "Service") {
+		return fmt.Errorf("%w: %v is only answered by the service interfaces", ErrUnsupportedFormat, format)
+	}
+	return nil
+}
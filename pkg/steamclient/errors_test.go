@@ -0,0 +1,108 @@
+package steamclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+	"github.com/xemkayx/steam-api/pkg/steamid"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIErrorUnwrap(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       error
+	}{
+		{name: "rate limited", statusCode: http.StatusTooManyRequests, want: ErrRateLimited},
+		{name: "unauthorized", statusCode: http.StatusUnauthorized, want: ErrPrivateProfile},
+		{name: "forbidden private profile", statusCode: http.StatusForbidden, body: `{"playerstats":{"error":"Profile is not public","success":false}}`, want: ErrPrivateProfile},
+		{name: "forbidden invalid key", statusCode: http.StatusForbidden, body: "Access is denied", want: nil},
+		{name: "server error", statusCode: http.StatusServiceUnavailable, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &APIError{StatusCode: tt.statusCode, Body: tt.body}
+			assert.Equal(t, tt.want, err.Unwrap())
+		})
+	}
+}
+
+func TestEndpointErrors(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantIs     error
+	}{
+		{name: "503", statusCode: http.StatusServiceUnavailable, body: "Service Unavailable"},
+		{name: "429", statusCode: http.StatusTooManyRequests, wantIs: ErrRateLimited},
+		{name: "401", statusCode: http.StatusUnauthorized, body: "<html>Unauthorized</html>", wantIs: ErrPrivateProfile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamUser/GetFriendList/v1",
+				httpmock.NewStringResponder(tt.statusCode, tt.body))
+
+			client := New("test-key", &http.Client{})
+			got, err := client.GetFriendList(GetFriendListParams{SteamId: 76561197960435530, Format: config.Json})
+			assert.Nil(t, got)
+
+			var apiErr *APIError
+			if assert.True(t, errors.As(err, &apiErr), "expected *APIError, got %v", err) {
+				assert.Equal(t, tt.statusCode, apiErr.StatusCode)
+				assert.Equal(t, ISteamUser, apiErr.Interface)
+				assert.Equal(t, GetFriendListEndpoint, apiErr.Endpoint)
+				assert.Equal(t, "1", apiErr.Version)
+				assert.Equal(t, tt.body, apiErr.Body)
+			}
+			if tt.wantIs != nil {
+				assert.ErrorIs(t, err, tt.wantIs)
+			}
+		})
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/GetRecentlyPlayedGames/v1",
+		httpmock.NewStringResponder(200, ""))
+
+	client := NewClientWithoutKey(&http.Client{})
+	_, err := client.GetOwnedGames(GetOwnedGamesParams{SteamId: 1, Format: config.Json})
+	assert.ErrorIs(t, err, ErrKeyRequired)
+
+	client = New("test-key", &http.Client{})
+	_, err = client.GetPlayerSummaries(GetPlayerSummariesParams{SteamIds: make([]steamid.ID, 101), Format: config.Json})
+	assert.ErrorIs(t, err, ErrTooManyIDs)
+
+	_, err = client.GetRecentlyPlayedGames(GetRecentlyPlayedGamesParams{SteamId: 1, Format: config.OutputFormat(42)})
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestProtobufRejectedBeforeSending(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := New("test-key", &http.Client{})
+	_, err := client.GetNewsForApp(GetNewsForAppParams{AppId: 440, Format: config.Protobuf})
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	_, err = client.GetPlayerSummaries(GetPlayerSummariesParams{SteamIds: []steamid.ID{76561197960435530}, Format: config.Protobuf})
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	_, err = client.Send(context.Background(), NewRequest(ISteamUserStats, GetSchemaForGameEndpoint, 2).Post().Format(config.Protobuf))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	assert.Equal(t, 0, httpmock.GetTotalCallCount(), "nothing is sent to interfaces that can't answer protobuf")
+}
@@ -0,0 +1,105 @@
+package steamclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFamilySharingStatus(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	tests := []struct {
+		name         string
+		sharedStatus int
+		shared       string
+		ownedStatus  int
+		owned        string
+		want         *FamilySharing
+		wantErr      bool
+	}{
+		{
+			name:         "borrower",
+			sharedStatus: 200, shared: `{"response":{"lender_steamid":"76561197960287930"}}`,
+			want: &FamilySharing{Status: SharingBorrower, LenderSteamId: 76561197960287930},
+		},
+		{
+			name:         "owner",
+			sharedStatus: 200, shared: `{"response":{"lender_steamid":"0"}}`,
+			ownedStatus: 200, owned: `{"response":{"game_count":1,"games":[{"appid":440,"playtime_forever":69}]}}`,
+			want: &FamilySharing{Status: SharingOwner},
+		},
+		{
+			name:         "unknown with game_count 0",
+			sharedStatus: 200, shared: `{"response":{"lender_steamid":"0"}}`,
+			ownedStatus: 200, owned: `{"response":{"game_count":0}}`,
+			want: &FamilySharing{Status: SharingUnknown},
+		},
+		{
+			name:         "private game list without game_count",
+			sharedStatus: 200, shared: `{"response":{"lender_steamid":"0"}}`,
+			ownedStatus: 200, owned: `{"response":{}}`,
+			want: &FamilySharing{Status: SharingPrivate},
+		},
+		{
+			name:         "private profile",
+			sharedStatus: 401, shared: `<html><body>Unauthorized</body></html>`,
+			ownedStatus: 401, owned: `<html><body>Unauthorized</body></html>`,
+			want: &FamilySharing{Status: SharingPrivate},
+		},
+		{
+			name:         "error",
+			sharedStatus: 500, shared: ``,
+			wantErr: true,
+		},
+		{
+			name:         "error of GetOwnedGames",
+			sharedStatus: 200, shared: `{"response":{"lender_steamid":"0"}}`,
+			ownedStatus: 503, owned: ``,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/IsPlayingSharedGame/v1",
+				func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "76561197960435530", req.URL.Query().Get("steamid"))
+					assert.Equal(t, "440", req.URL.Query().Get("appid_playing"))
+					return httpmock.NewStringResponse(tt.sharedStatus, tt.shared), nil
+				})
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/IPlayerService/GetOwnedGames/v1",
+				func(req *http.Request) (*http.Response, error) {
+					var input map[string]any
+					decoder := json.NewDecoder(strings.NewReader(req.URL.Query().Get("input_json")))
+					decoder.UseNumber()
+					assert.NoError(t, decoder.Decode(&input))
+					assert.Equal(t, map[string]any{
+						"steamid":                   json.Number("76561197960435530"),
+						"include_played_free_games": true,
+						"appids_filter":             []any{json.Number("440")},
+					}, input)
+					return httpmock.NewStringResponse(tt.ownedStatus, tt.owned), nil
+				})
+
+			got, err := New("test-key", &http.Client{}).FamilySharingStatus(context.Background(), 76561197960435530, 440)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := NewClientWithoutKey(&http.Client{}).FamilySharingStatus(context.Background(), 76561197960435530, 440)
+	assert.ErrorIs(t, err, ErrKeyRequired)
+	assert.Equal(t, "borrower", SharingBorrower.String())
+}
@@ -0,0 +1,63 @@
+package steamclient
+
+import "net/http"
+
+/*
+Middleware wraps the http.RoundTripper requests are sent with, e.g. to add headers, tracing or metrics:
+
+	func metrics(next http.RoundTripper) http.RoundTripper {
+		return steamclient.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			requestDuration.Observe(time.Since(start).Seconds())
+			return resp, err
+		})
+	}
+*/
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is a function that implements http.RoundTripper
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+/*
+Use adds middleware to the Client. The first middleware is the outermost one, so it sees the requests first.
+Every attempt of a retried request passes the middleware; cached responses don't.
+
+The middleware is wrapped around the transport of HttpClient once, here, so set HttpClient before calling Use.
+Calling Use again rebuilds the chain with all middleware of the Client.
+*/
+func (c *Client) Use(mw ...Middleware) {
+	c.middlewares = append(c.middlewares[:len(c.middlewares):len(c.middlewares)], mw...)
+
+	var transport http.RoundTripper
+	if c.HttpClient != nil {
+		transport = c.HttpClient.Transport
+	}
+	if transport == nil {
+		// resolve the default transport on every request, so replacing http.DefaultTransport (e.g. by httpmock) still works
+		transport = RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return http.DefaultTransport.RoundTrip(req)
+		})
+	}
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		transport = c.middlewares[i](transport)
+	}
+	c.transport = transport
+}
+
+// httpClient returns the http.Client to send requests with, its transport replaced by the middleware chain of c
+func (c Client) httpClient() *http.Client {
+	if c.transport == nil {
+		return c.HttpClient
+	}
+	httpClient := http.Client{}
+	if c.HttpClient != nil {
+		httpClient = *c.HttpClient
+	}
+	httpClient.Transport = c.transport
+	return &httpClient
+}
@@ -0,0 +1,24 @@
+package model
+
+type BadgesWrapper struct {
+	Badges Badges `json:"response" xml:"response"`
+}
+
+type Badges struct {
+	Badges                     []Badge `json:"badges" xml:"badges>message"`
+	PlayerXP                   int     `json:"player_xp" xml:"player_xp"`
+	PlayerLevel                int     `json:"player_level" xml:"player_level"`
+	PlayerXPNeededToLevelUp    int     `json:"player_xp_needed_to_level_up" xml:"player_xp_needed_to_level_up"`     // XP still missing for the next level
+	PlayerXPNeededCurrentLevel int     `json:"player_xp_needed_current_level" xml:"player_xp_needed_current_level"` // Total XP the current level needs
+}
+
+type Badge struct {
+	BadgeId         int    `json:"badgeid" xml:"badgeid"`
+	Level           int    `json:"level" xml:"level"`
+	CompletionTime  int64  `json:"completion_time" xml:"completion_time"` // Unix timestamp
+	XP              int    `json:"xp" xml:"xp"`
+	Scarcity        int    `json:"scarcity" xml:"scarcity"`                                   // Number of players with this badge
+	AppId           uint32 `json:"appid,omitempty" xml:"appid,omitempty"`                     // Only set for game badges
+	CommunityItemId string `json:"communityitemid,omitempty" xml:"communityitemid,omitempty"` // Only set for game badges
+	BorderColor     int    `json:"border_color,omitempty" xml:"border_color,omitempty"`       // Only set for game badges, 1 for foil badges
+}
@@ -0,0 +1,14 @@
+package model
+
+type CommunityBadgeProgressWrapper struct {
+	CommunityBadgeProgress CommunityBadgeProgress `json:"response" xml:"response"`
+}
+
+type CommunityBadgeProgress struct {
+	Quests []Quest `json:"quests" xml:"quests>message"`
+}
+
+type Quest struct {
+	QuestId   int  `json:"questid" xml:"questid"`
+	Completed bool `json:"completed" xml:"completed"`
+}
@@ -0,0 +1,5 @@
+package model
+
+// The protobuf messages of the implemented IPlayerService methods are generated from player.proto, a subset of
+// steammessages_player.steamclient.proto of the Steam client.
+//go:generate go run github.com/xemkayx/steam-api/cmd/steamapi-protogen -in player.proto -out player.pb.go
@@ -34,6 +34,15 @@ type Game struct {
 	PlaytimeDeckForever    int      `json:"playtime_deck_forever" xml:"playtime_deck_forever"`
 	PlaytimeDisconnected   uint32   `json:"playtime_disconnected" xml:"playtime_disconnected"`
 	ContentDescriptorIds   []uint32 `json:"content_descriptorids,omitempty" xml:"content_descriptorids>uint32,omitempty"`
+
+	// Only set with include_appinfo. The JSON output leaves some of them out, the protobuf output has all of them.
+	HasCommunityVisibleStats bool   `json:"has_community_visible_stats,omitempty" xml:"has_community_visible_stats,omitempty"`
+	CapsuleFilename          string `json:"capsule_filename,omitempty" xml:"capsule_filename,omitempty"`
+	SortAs                   string `json:"sort_as,omitempty" xml:"sort_as,omitempty"`
+	HasWorkshop              bool   `json:"has_workshop,omitempty" xml:"has_workshop,omitempty"`
+	HasMarket                bool   `json:"has_market,omitempty" xml:"has_market,omitempty"`
+	HasDLC                   bool   `json:"has_dlc,omitempty" xml:"has_dlc,omitempty"`
+	HasLeaderboards          bool   `json:"has_leaderboards,omitempty" xml:"has_leaderboards,omitempty"`
 }
 
 // type ContentDescriptorId struct {
@@ -0,0 +1,11 @@
+package model
+
+import "github.com/xemkayx/steam-api/pkg/steamid"
+
+type SharedGameWrapper struct {
+	SharedGame SharedGame `json:"response" xml:"response"`
+}
+
+type SharedGame struct {
+	LenderSteamId steamid.ID `json:"lender_steamid" xml:"lender_steamid"` // 0 if the player doesn't play a borrowed copy
+}
@@ -0,0 +1,9 @@
+package model
+
+type SteamLevelWrapper struct {
+	SteamLevel SteamLevel `json:"response" xml:"response"`
+}
+
+type SteamLevel struct {
+	PlayerLevel int `json:"player_level" xml:"player_level"`
+}
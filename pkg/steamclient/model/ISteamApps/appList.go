@@ -0,0 +1,14 @@
+package model
+
+type AppListWrapper struct {
+	AppList AppList `json:"applist" xml:"applist"`
+}
+
+type AppList struct {
+	Apps []App `json:"apps" xml:"apps>app"`
+}
+
+type App struct {
+	AppId uint32 `json:"appid" xml:"appid"`
+	Name  string `json:"name" xml:"name"` // can be empty for unreleased or removed apps
+}
@@ -0,0 +1,27 @@
+package model
+
+import "github.com/xemkayx/steam-api/pkg/steamid"
+
+type ServersAtAddressWrapper struct {
+	Response ServersAtAddress `json:"response" xml:"response"`
+}
+
+type ServersAtAddress struct {
+	Success bool     `json:"success" xml:"success"`
+	Servers []Server `json:"servers" xml:"servers>server"`
+	Message string   `json:"message,omitempty" xml:"message,omitempty"` // Only set if the request failed, e.g. for an invalid address
+}
+
+// Server is a game server registered with the master server
+type Server struct {
+	Addr     string     `json:"addr" xml:"addr"` // IP:queryport
+	GMSIndex int        `json:"gmsindex" xml:"gmsindex"`
+	SteamID  steamid.ID `json:"steamid" xml:"steamid"`
+	AppId    uint32     `json:"appid" xml:"appid"`
+	GameDir  string     `json:"gamedir" xml:"gamedir"`
+	Region   int        `json:"region" xml:"region"`
+	Secure   bool       `json:"secure" xml:"secure"` // VAC secured
+	Lan      bool       `json:"lan" xml:"lan"`
+	GamePort int        `json:"gameport" xml:"gameport"`
+	SpecPort int        `json:"specport" xml:"specport"`
+}
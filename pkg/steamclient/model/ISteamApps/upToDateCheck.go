@@ -0,0 +1,13 @@
+package model
+
+type UpToDateCheckWrapper struct {
+	Response UpToDateCheck `json:"response" xml:"response"`
+}
+
+type UpToDateCheck struct {
+	Success           bool   `json:"success" xml:"success"`
+	UpToDate          bool   `json:"up_to_date" xml:"up_to_date"`
+	VersionIsListable bool   `json:"version_is_listable" xml:"version_is_listable"`               // servers with this version are shown in the server browser
+	RequiredVersion   uint32 `json:"required_version,omitempty" xml:"required_version,omitempty"` // Only set if the version is out of date
+	Message           string `json:"message,omitempty" xml:"message,omitempty"`
+}
@@ -1,5 +1,7 @@
 package model
 
+import "github.com/xemkayx/steam-api/pkg/steamid"
+
 type FriendListWrapper struct {
 	FriendsList FriendList `json:"friendslist" xml:"friendslist"`
 }
@@ -9,7 +11,7 @@ type FriendList struct {
 }
 
 type Friend struct {
-	SteamId      string `json:"steamid" xml:"steamid"`
-	Relationship string `json:"relationship" xml:"relationship"`
-	FriendSince  int64  `json:"friend_since" xml:"friend_since"`
+	SteamId      steamid.ID `json:"steamid" xml:"steamid"`
+	Relationship string     `json:"relationship" xml:"relationship"`
+	FriendSince  int64      `json:"friend_since" xml:"friend_since"`
 }
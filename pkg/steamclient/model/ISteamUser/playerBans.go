@@ -0,0 +1,25 @@
+package model
+
+import "github.com/xemkayx/steam-api/pkg/steamid"
+
+type PlayerBans struct {
+	Players []PlayerBan `json:"players" xml:"players>player"`
+}
+
+// PlayerBan holds the ban status of a single player
+type PlayerBan struct {
+	SteamID          steamid.ID `json:"SteamId" xml:"SteamId"`
+	CommunityBanned  bool       `json:"CommunityBanned" xml:"CommunityBanned"`
+	VACBanned        bool       `json:"VACBanned" xml:"VACBanned"`
+	NumberOfVACBans  int        `json:"NumberOfVACBans" xml:"NumberOfVACBans"`
+	DaysSinceLastBan int        `json:"DaysSinceLastBan" xml:"DaysSinceLastBan"` // 0 if the player was never banned
+	NumberOfGameBans int        `json:"NumberOfGameBans" xml:"NumberOfGameBans"`
+	EconomyBan       string     `json:"EconomyBan" xml:"EconomyBan"` // none, probation or banned
+}
+
+// Values of PlayerBan.EconomyBan
+const (
+	EconomyBanNone      = "none"
+	EconomyBanProbation = "probation"
+	EconomyBanBanned    = "banned"
+)
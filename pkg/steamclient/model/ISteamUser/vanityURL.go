@@ -0,0 +1,19 @@
+package model
+
+import "github.com/xemkayx/steam-api/pkg/steamid"
+
+type ResolveVanityURLWrapper struct {
+	Response ResolvedVanityURL `json:"response" xml:"response"`
+}
+
+type ResolvedVanityURL struct {
+	SteamID steamid.ID `json:"steamid,omitempty" xml:"steamid,omitempty"` // Only set on success
+	Success int        `json:"success" xml:"success"`                     // 1 - match, 42 - no match
+	Message string     `json:"message,omitempty" xml:"message,omitempty"` // Only set if there was no match
+}
+
+// Values of ResolvedVanityURL.Success
+const (
+	VanityURLMatch   = 1
+	VanityURLNoMatch = 42
+)
@@ -1,8 +1,72 @@
 package model
 
-// TODO - unknown output format
-type GameStatsResponse struct {
-	Response struct {
-		GlobalStats map[string]interface{} `json:"globalstats"`
-	} `json:"response"`
+import "encoding/xml"
+
+type GlobalStatsForGameWrapper struct {
+	Response GlobalStatsForGame `json:"response" xml:"response"`
+}
+
+type GlobalStatsForGame struct {
+	GlobalStats GlobalStatMap `json:"globalstats" xml:"globalstats"`
+	Result      int           `json:"result" xml:"result"`                   // 1 on success
+	Error       string        `json:"error,omitempty" xml:"error,omitempty"` // Only set if the request failed
+}
+
+// GlobalStatMap maps the names of the requested stats to their totals
+type GlobalStatMap map[string]GlobalStat
+
+type GlobalStat struct {
+	Total   int64   `json:"total,string" xml:"total"`
+	History History `json:"history,omitempty" xml:"history,omitempty"` // Only set if a start date was given
+}
+
+// History holds the daily totals of a stat, oldest first
+type History []DailyTotal
+
+type DailyTotal struct {
+	Date  int64 `json:"date" xml:"date"` // Unix timestamp of the start of the day
+	Total int64 `json:"total,string" xml:"total"`
+}
+
+// UnmarshalXML decodes the stats, which are elements named after the stat in the XML output
+func (m *GlobalStatMap) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if *m == nil {
+		*m = GlobalStatMap{}
+	}
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var stat GlobalStat
+			if err := d.DecodeElement(&stat, &t); err != nil {
+				return err
+			}
+			(*m)[t.Name.Local] = stat
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// UnmarshalXML decodes the daily totals regardless of the name of their elements
+func (h *History) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var day DailyTotal
+			if err := d.DecodeElement(&day, &t); err != nil {
+				return err
+			}
+			*h = append(*h, day)
+		case xml.EndElement:
+			return nil
+		}
+	}
 }
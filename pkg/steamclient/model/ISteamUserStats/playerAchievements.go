@@ -1,12 +1,14 @@
 package model
 
+import "github.com/xemkayx/steam-api/pkg/steamid"
+
 type PlayerStatsWrapper struct {
 	PlayerStats PlayerAchievements `json:"playerstats"`
 }
 
 // PlayerStats - Second level that contains details about the player and achievements
 type PlayerAchievements struct {
-	SteamID      string        `json:"steamID" xml:"steamID"`
+	SteamID      steamid.ID    `json:"steamID" xml:"steamID"`
 	GameName     string        `json:"gameName" xml:"gameName"`
 	Achievements []Achievement `json:"achievements" xml:"achievements>achievement"`
 }
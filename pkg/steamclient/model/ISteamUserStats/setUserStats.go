@@ -0,0 +1,20 @@
+package model
+
+type SetUserStatsForGameWrapper struct {
+	Response SetUserStatsForGame `json:"response" xml:"response"`
+}
+
+type SetUserStatsForGame struct {
+	Result int          `json:"result" xml:"result"`                   // 1 on success
+	Error  string       `json:"error,omitempty" xml:"error,omitempty"` // Only set if the request failed
+	Stats  []StatResult `json:"-" xml:"-"`                             // The sent stats, in the order they were passed
+}
+
+// StatResult is a stat or achievement sent with SetUserStatsForGame. Steam only reports a result for the whole
+// call, so Set is the same for every entry of a response.
+type StatResult struct {
+	Name        string
+	Value       float64
+	Achievement bool // the name is an achievement of the schema; only known if a schema was passed
+	Set         bool // Steam accepted the call, i.e. the Result of the response is 1
+}
@@ -1,12 +1,14 @@
 // user stats for a specific game
 package model
 
+import "github.com/xemkayx/steam-api/pkg/steamid"
+
 type UserStatsResponse struct {
 	PlayerStats UserStats `json:"playerstats" xml:"playerstats"`
 }
 
 type UserStats struct {
-	SteamID      string                 `json:"steamID" xml:"steamID"`
+	SteamID      steamid.ID             `json:"steamID" xml:"steamID"`
 	GameName     string                 `json:"gameName" xml:"gameName"`
 	Stats        []Stat                 `json:"stats" xml:"stats>stat"`
 	Achievements []UserStatsAchievement `json:"achievements" xml:"achievements>achievement"`
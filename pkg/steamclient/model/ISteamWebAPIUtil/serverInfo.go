@@ -0,0 +1,7 @@
+package model
+
+// ServerInfo holds the time of the Steam Web API servers
+type ServerInfo struct {
+	ServerTime       int64  `json:"servertime" xml:"servertime"`             // Unix timestamp
+	ServerTimeString string `json:"servertimestring" xml:"servertimestring"` // e.g. Sat Oct 17 12:00:00 2026
+}
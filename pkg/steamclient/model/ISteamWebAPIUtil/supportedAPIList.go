@@ -0,0 +1,54 @@
+package model
+
+type SupportedAPIListWrapper struct {
+	APIList SupportedAPIList `json:"apilist" xml:"apilist"`
+}
+
+// SupportedAPIList lists the interfaces and methods that can be called with the used key
+type SupportedAPIList struct {
+	Interfaces []Interface `json:"interfaces" xml:"interfaces>interface"`
+}
+
+type Interface struct {
+	Name    string   `json:"name" xml:"name"`
+	Methods []Method `json:"methods" xml:"methods>method"`
+}
+
+type Method struct {
+	Name        string      `json:"name" xml:"name"`
+	Version     int         `json:"version" xml:"version"`
+	HTTPMethod  string      `json:"httpmethod" xml:"httpmethod"` // GET or POST
+	Description string      `json:"description,omitempty" xml:"description,omitempty"`
+	Parameters  []Parameter `json:"parameters" xml:"parameters>parameter"`
+}
+
+type Parameter struct {
+	Name        string `json:"name" xml:"name"` // lists are named like name[0]
+	Type        string `json:"type" xml:"type"` // e.g. uint32, string, bool, {enum} or {message}
+	Optional    bool   `json:"optional" xml:"optional"`
+	Description string `json:"description,omitempty" xml:"description,omitempty"`
+}
+
+// Interface returns the interface with the given name
+func (l SupportedAPIList) Interface(name string) (Interface, bool) {
+	for _, i := range l.Interfaces {
+		if i.Name == name {
+			return i, true
+		}
+	}
+	return Interface{}, false
+}
+
+// Method returns the given version of a method of the interface
+func (l SupportedAPIList) Method(iface, method string, version int) (Method, bool) {
+	i, ok := l.Interface(iface)
+	if !ok {
+		return Method{}, false
+	}
+	for _, m := range i.Methods {
+		if m.Name == method && m.Version == version {
+			return m, true
+		}
+	}
+	return Method{}, false
+}
@@ -0,0 +1,19 @@
+package model
+
+type AppListWrapper struct {
+	Response AppList `json:"response" xml:"response"`
+}
+
+// AppList is one page of the apps on the Steam Store
+type AppList struct {
+	Apps            []App  `json:"apps" xml:"apps>message"`
+	HaveMoreResults bool   `json:"have_more_results" xml:"have_more_results"`
+	LastAppId       uint32 `json:"last_appid" xml:"last_appid"` // pass it as last_appid to get the next page
+}
+
+type App struct {
+	AppId             uint32 `json:"appid" xml:"appid"`
+	Name              string `json:"name" xml:"name"`
+	LastModified      int64  `json:"last_modified" xml:"last_modified"` // Unix timestamp of the last change of the description, title or tags
+	PriceChangeNumber uint32 `json:"price_change_number" xml:"price_change_number"`
+}
@@ -0,0 +1,117 @@
+package steamclient
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created with NewWithOptions
+type Option func(*options)
+
+type options struct {
+	client      Client
+	timeout     time.Duration
+	middlewares []Middleware
+}
+
+/*
+NewWithOptions creates a Client configured by opts.
+
+Without options, the Client has no key and sends its requests with a new http.Client:
+
+	client := steamclient.NewWithOptions(
+		steamclient.WithKey("your-api-key"),
+		steamclient.WithTimeout(10*time.Second),
+		steamclient.WithRetry(&steamclient.RetryPolicy{MaxAttempts: 3}),
+	)
+*/
+func NewWithOptions(opts ...Option) *Client {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	c := o.client
+	c.flights = &flightGroup{}
+	if c.HttpClient == nil {
+		c.HttpClient = &http.Client{}
+	}
+	if o.timeout > 0 {
+		// copy the http.Client, it may be shared with other code
+		httpClient := *c.HttpClient
+		httpClient.Timeout = o.timeout
+		c.HttpClient = &httpClient
+	}
+	if len(o.middlewares) > 0 {
+		c.Use(o.middlewares...)
+	}
+	return &c
+}
+
+// WithKey sets the API-key
+func WithKey(key string) Option {
+	return func(o *options) { o.client.Key = key }
+}
+
+// WithPublisherKey sets a publisher key, so publisher-only methods are sent to the partner host
+func WithPublisherKey(key string) Option {
+	return func(o *options) {
+		o.client.Key = key
+		o.client.PublisherKey = true
+	}
+}
+
+// WithHTTPClient sets the http.Client the requests are sent with
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) { o.client.HttpClient = httpClient }
+}
+
+// WithAPIBaseURL sets the base URL of the Web API, e.g. to use a caching proxy. See Client.BaseURL.
+// WithBaseURL changes it for a single request.
+func WithAPIBaseURL(baseURL string) Option {
+	return func(o *options) { o.client.BaseURL = baseURL }
+}
+
+// WithPartnerBaseURL sets the base URL publisher-only methods are sent to. See Client.PartnerBaseURL.
+func WithPartnerBaseURL(baseURL string) Option {
+	return func(o *options) { o.client.PartnerBaseURL = baseURL }
+}
+
+// WithUserAgent sets the User-Agent header of all requests
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) { o.client.UserAgent = userAgent }
+}
+
+// WithLogger sets the logger the requests are logged to (default: slog.Default())
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) { o.client.Logger = logger }
+}
+
+// WithTimeout sets the timeout of the http.Client. The http.Client passed to WithHTTPClient is not changed.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.timeout = timeout }
+}
+
+// WithRetry sets the retry policy of failed GET requests
+func WithRetry(policy *RetryPolicy) Option {
+	return func(o *options) { o.client.Retry = policy }
+}
+
+// WithCache sets the cache of successful GET responses and overrides of their default TTLs (may be nil)
+func WithCache(cache Cache, ttls map[string]time.Duration) Option {
+	return func(o *options) {
+		o.client.Cache = cache
+		o.client.CacheTTLs = ttls
+	}
+}
+
+// WithRateLimiter sets the rate limiter. It can be shared with other Clients.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *options) { o.client.RateLimiter = limiter }
+}
+
+// WithMiddleware adds middleware to the Client, see Client.Use
+func WithMiddleware(mw ...Middleware) Option {
+	return func(o *options) { o.middlewares = append(o.middlewares, mw...) }
+}
@@ -0,0 +1,135 @@
+package steamclient
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWithOptionsDefaults(t *testing.T) {
+	client := NewWithOptions()
+
+	assert.NotNil(t, client.HttpClient)
+	assert.False(t, client.IsKeySet())
+	assert.Nil(t, client.Retry)
+	assert.Nil(t, client.Cache)
+	assert.Nil(t, client.RateLimiter)
+}
+
+func TestNewWithOptions(t *testing.T) {
+	httpClient := &http.Client{Timeout: time.Minute}
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	retry := &RetryPolicy{MaxAttempts: 5}
+	cache := NewLRUCache(10)
+	ttls := map[string]time.Duration{"ISteamNews/GetNewsForApp": time.Second}
+	limiter := NewRateLimiter(RateLimiterOptions{})
+
+	client := NewWithOptions(
+		WithPublisherKey("publisher-key"),
+		WithHTTPClient(httpClient),
+		WithAPIBaseURL("http://localhost:8080"),
+		WithPartnerBaseURL("http://localhost:8081"),
+		WithUserAgent("my-app/1.0"),
+		WithLogger(logger),
+		WithTimeout(5*time.Second),
+		WithRetry(retry),
+		WithCache(cache, ttls),
+		WithRateLimiter(limiter),
+	)
+
+	assert.Equal(t, "publisher-key", client.Key)
+	assert.True(t, client.PublisherKey)
+	assert.Equal(t, "http://localhost:8080", client.BaseURL)
+	assert.Equal(t, "http://localhost:8081", client.PartnerBaseURL)
+	assert.Equal(t, "my-app/1.0", client.UserAgent)
+	assert.Same(t, logger, client.Logger)
+	assert.Same(t, retry, client.Retry)
+	assert.Same(t, cache, client.Cache)
+	assert.Equal(t, ttls, client.CacheTTLs)
+	assert.Same(t, limiter, client.RateLimiter)
+
+	assert.Equal(t, 5*time.Second, client.HttpClient.Timeout)
+	assert.Equal(t, time.Minute, httpClient.Timeout, "the passed http.Client should not be changed")
+
+	client = NewWithOptions(WithKey("test-key"))
+	assert.Equal(t, "test-key", client.Key)
+	assert.False(t, client.PublisherKey)
+}
+
+func TestClientMiddleware(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamNews/GetNewsForApp/v2",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "my-app/1.0", req.Header.Get("User-Agent"))
+			assert.Equal(t, "first,second", req.Header.Get("X-Middleware"))
+			return httpmock.NewStringResponse(200, `{"appnews":{"appid":440}}`), nil
+		})
+
+	var order []string
+	middleware := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				if v := req.Header.Get("X-Middleware"); v != "" {
+					name = v + "," + name
+				}
+				req.Header.Set("X-Middleware", name)
+				resp, err := next.RoundTrip(req)
+				order = append(order, name+" done")
+				return resp, err
+			})
+		}
+	}
+
+	var logs bytes.Buffer
+	client := NewWithOptions(
+		WithKey("secret-key"),
+		WithUserAgent("my-app/1.0"),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		WithMiddleware(middleware("first")),
+	)
+	client.Use(middleware("second"))
+
+	got, err := client.GetNewsForApp(GetNewsForAppParams{AppId: 440, Format: config.Json})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(440), got.AppId)
+	assert.Equal(t, []string{"first", "second", "first,second done", "first done"}, order)
+
+	assert.Contains(t, logs.String(), "ISteamNews/GetNewsForApp")
+	assert.NotContains(t, logs.String(), "secret-key")
+	assert.Nil(t, client.HttpClient.Transport, "the http.Client should not be changed by the middleware")
+}
+
+func TestClientMiddlewareBuiltOnce(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamNews/GetNewsForApp/v2",
+		httpmock.NewStringResponder(200, `{"appnews":{"appid":440}}`))
+
+	var built, requests int
+	counter := func(next http.RoundTripper) http.RoundTripper {
+		built++
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			requests++
+			return next.RoundTrip(req)
+		})
+	}
+
+	client := NewWithOptions(WithKey("test-key"), WithMiddleware(counter))
+	for i := 0; i < 3; i++ {
+		_, err := client.GetNewsForApp(GetNewsForAppParams{AppId: 440, Format: config.Json})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, built, "the middleware chain should be built once, not per request")
+	assert.Equal(t, 3, requests)
+}
@@ -0,0 +1,184 @@
+package steamclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Defaults of the RateLimiter. Steam allows about 100.000 calls per key and day.
+const (
+	DefaultDailyQuota        = 100000
+	defaultRequestsPerSecond = 10
+	defaultBurst             = 10
+	defaultNearQuota         = 0.9
+	defaultThrottlePause     = 10 * time.Second // pause after a 429 response without Retry-After header
+)
+
+// ErrDailyQuotaExceeded is returned by requests that would exceed the daily quota of a RateLimiter
+var ErrDailyQuotaExceeded = errors.New("the daily request quota of the API-key is used up")
+
+// Options of a RateLimiter. The zero value uses the defaults.
+type RateLimiterOptions struct {
+	RequestsPerSecond float64 // Sustained number of requests per second and key (default: 10)
+	Burst             int     // Number of requests that can be sent at once after a quiet period (default: 10)
+
+	DailyQuota int     // Number of requests per key and day (UTC), -1 disables the quota (default: 100000)
+	NearQuota  float64 // Fraction of the daily quota at which OnNearQuota is called (default: 0.9)
+
+	// Called once per key and day when the used requests reach NearQuota of the daily quota.
+	// It runs on the goroutine of the request and should not block.
+	OnNearQuota func(key string, used, quota int)
+}
+
+/*
+RateLimiter throttles the requests of a Client with a token bucket per API-key and counts them against a daily quota.
+
+It's safe for concurrent use, so one Client (or several Clients sharing the limiter) can be used by a whole
+worker pool. Requests wait for a free token as long as their context allows; requests beyond the daily quota
+fail with ErrDailyQuotaExceeded. When Steam answers with 429 Too Many Requests, the key is paused for the
+duration of the Retry-After header.
+*/
+type RateLimiter struct {
+	opts RateLimiterOptions
+	now  func() time.Time
+
+	mu   sync.Mutex
+	keys map[string]*keyLimit
+}
+
+// state of a single API-key
+type keyLimit struct {
+	tokens      float64
+	last        time.Time // last refill of tokens
+	pausedUntil time.Time
+
+	day      time.Time // start of the day (UTC) used is counted for
+	used     int
+	notified bool
+}
+
+// NewRateLimiter creates a RateLimiter with the given options
+func NewRateLimiter(opts RateLimiterOptions) *RateLimiter {
+	if opts.RequestsPerSecond <= 0 {
+		opts.RequestsPerSecond = defaultRequestsPerSecond
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = defaultBurst
+	}
+	if opts.DailyQuota == 0 {
+		opts.DailyQuota = DefaultDailyQuota
+	}
+	if opts.NearQuota <= 0 || opts.NearQuota > 1 {
+		opts.NearQuota = defaultNearQuota
+	}
+	return &RateLimiter{opts: opts, now: time.Now, keys: map[string]*keyLimit{}}
+}
+
+// Wait blocks until a request with the given key may be sent. It returns ctx.Err() if ctx ends first and
+// ErrDailyQuotaExceeded if the key has no requests left today.
+func (l *RateLimiter) Wait(ctx context.Context, key string) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		delay, err := l.reserve(key)
+		if err != nil {
+			return err
+		}
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token and counts the request if one is available, otherwise it returns how long to wait
+func (l *RateLimiter) reserve(key string) (time.Duration, error) {
+	l.mu.Lock()
+
+	now := l.now()
+	k := l.key(key, now)
+
+	if l.opts.DailyQuota > 0 && k.used >= l.opts.DailyQuota {
+		l.mu.Unlock()
+		return 0, ErrDailyQuotaExceeded
+	}
+	if now.Before(k.pausedUntil) {
+		l.mu.Unlock()
+		return k.pausedUntil.Sub(now), nil
+	}
+
+	k.tokens = min(float64(l.opts.Burst), k.tokens+now.Sub(k.last).Seconds()*l.opts.RequestsPerSecond)
+	k.last = now
+	if k.tokens < 1 {
+		missing := 1 - k.tokens
+		l.mu.Unlock()
+		return time.Duration(missing / l.opts.RequestsPerSecond * float64(time.Second)), nil
+	}
+	k.tokens--
+	k.used++
+
+	notify := false
+	if l.opts.DailyQuota > 0 && !k.notified && float64(k.used) >= l.opts.NearQuota*float64(l.opts.DailyQuota) {
+		k.notified = true
+		notify = l.opts.OnNearQuota != nil
+	}
+	used := k.used
+	l.mu.Unlock()
+
+	if notify {
+		l.opts.OnNearQuota(key, used, l.opts.DailyQuota)
+	}
+	return 0, nil
+}
+
+// key returns the state of key, starting a new day if necessary. l.mu has to be held.
+func (l *RateLimiter) key(key string, now time.Time) *keyLimit {
+	day := now.UTC().Truncate(24 * time.Hour)
+	k, ok := l.keys[key]
+	if !ok {
+		k = &keyLimit{tokens: float64(l.opts.Burst), last: now, day: day}
+		l.keys[key] = k
+	}
+	if !k.day.Equal(day) {
+		k.day, k.used, k.notified = day, 0, false
+	}
+	return k
+}
+
+// Pause stops all requests of key for d, e.g. after Steam answered with 429 Too Many Requests
+func (l *RateLimiter) Pause(key string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	k := l.key(key, now)
+	if until := now.Add(d); until.After(k.pausedUntil) {
+		k.pausedUntil = until
+	}
+}
+
+// Used returns the number of requests sent with key today
+func (l *RateLimiter) Used(key string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.key(key, l.now()).used
+}
+
+// Remaining returns the number of requests key has left today, or -1 if there is no daily quota
+func (l *RateLimiter) Remaining(key string) int {
+	if l.opts.DailyQuota < 0 {
+		return -1
+	}
+	return max(0, l.opts.DailyQuota-l.Used(key))
+}
@@ -0,0 +1,188 @@
+package steamclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a settable time source for the RateLimiter
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestLimiter(opts RateLimiterOptions) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	l := NewRateLimiter(opts)
+	l.now = clock.Now
+	return l, clock
+}
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	l, clock := newTestLimiter(RateLimiterOptions{RequestsPerSecond: 2, Burst: 3})
+
+	for i := 0; i < 3; i++ {
+		delay, err := l.reserve("key")
+		assert.NoError(t, err)
+		assert.Zero(t, delay, "request %d of the burst should not wait", i)
+	}
+
+	delay, err := l.reserve("key")
+	assert.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, delay)
+
+	// other keys have their own bucket
+	delay, err = l.reserve("other-key")
+	assert.NoError(t, err)
+	assert.Zero(t, delay)
+
+	clock.Advance(500 * time.Millisecond)
+	delay, err = l.reserve("key")
+	assert.NoError(t, err)
+	assert.Zero(t, delay)
+	assert.Equal(t, 4, l.Used("key"))
+}
+
+func TestRateLimiterDailyQuota(t *testing.T) {
+	type call struct {
+		key         string
+		used, quota int
+	}
+	var calls []call
+	l, clock := newTestLimiter(RateLimiterOptions{
+		RequestsPerSecond: 1000,
+		Burst:             1000,
+		DailyQuota:        10,
+		NearQuota:         0.8,
+		OnNearQuota:       func(key string, used, quota int) { calls = append(calls, call{key, used, quota}) },
+	})
+
+	for i := 0; i < 10; i++ {
+		_, err := l.reserve("key")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []call{{"key", 8, 10}}, calls, "OnNearQuota should be called once")
+	assert.Equal(t, 0, l.Remaining("key"))
+
+	_, err := l.reserve("key")
+	assert.ErrorIs(t, err, ErrDailyQuotaExceeded)
+
+	// the quota is reset at midnight UTC
+	clock.Advance(12 * time.Hour)
+	_, err = l.reserve("key")
+	assert.NoError(t, err)
+	assert.Equal(t, 9, l.Remaining("key"))
+}
+
+func TestRateLimiterWithoutQuota(t *testing.T) {
+	l, _ := newTestLimiter(RateLimiterOptions{DailyQuota: -1})
+	_, err := l.reserve("key")
+	assert.NoError(t, err)
+	assert.Equal(t, -1, l.Remaining("key"))
+}
+
+func TestRateLimiterPause(t *testing.T) {
+	l, clock := newTestLimiter(RateLimiterOptions{})
+
+	l.Pause("key", 30*time.Second)
+	delay, err := l.reserve("key")
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, delay)
+
+	clock.Advance(30 * time.Second)
+	delay, err = l.reserve("key")
+	assert.NoError(t, err)
+	assert.Zero(t, delay)
+}
+
+func TestRateLimiterWaitContext(t *testing.T) {
+	l := NewRateLimiter(RateLimiterOptions{RequestsPerSecond: 0.001, Burst: 1})
+	assert.NoError(t, l.Wait(context.Background(), "key"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := l.Wait(ctx, "key")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 1, l.Used("key"), "a cancelled wait should not count")
+}
+
+func TestRateLimiterConcurrent(t *testing.T) {
+	l := NewRateLimiter(RateLimiterOptions{RequestsPerSecond: 1000, Burst: 10, DailyQuota: 50})
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var sent, rejected int
+	for i := 0; i < 80; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := l.Wait(context.Background(), "key")
+			mu.Lock()
+			defer mu.Unlock()
+			if errors.Is(err, ErrDailyQuotaExceeded) {
+				rejected++
+			} else if err == nil {
+				sent++
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 50, sent)
+	assert.Equal(t, 30, rejected)
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamUser/GetFriendList/v1",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(http.StatusTooManyRequests, "")
+			resp.Header.Set("Retry-After", "120")
+			return resp, nil
+		})
+
+	limiter, clock := newTestLimiter(RateLimiterOptions{DailyQuota: 2})
+	client := New("test-key", &http.Client{})
+	client.RateLimiter = limiter
+
+	_, err := client.GetFriendList(GetFriendListParams{SteamId: 76561197960435530, Format: config.Json})
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 120*time.Second, apiErr.RetryAfter)
+
+	// the 429 paused the key
+	delay, _ := limiter.reserve("test-key")
+	assert.Equal(t, 120*time.Second, delay)
+
+	clock.Advance(120 * time.Second)
+	_, err = client.GetFriendList(GetFriendListParams{SteamId: 76561197960435530, Format: config.Json})
+	assert.ErrorIs(t, err, ErrRateLimited)
+	_, err = client.GetFriendList(GetFriendListParams{SteamId: 76561197960435530, Format: config.Json})
+	assert.ErrorIs(t, err, ErrDailyQuotaExceeded)
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+}
@@ -0,0 +1,181 @@
+package steamclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	urlHelper "github.com/xemkayx/steam-api/internal/urlHelper"
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+)
+
+/*
+A Request describes a call of a Web API method. It's built with NewRequest and sent with Client.Do or Client.Send:
+
+	req := steamclient.NewRequest("IEconService", "GetTradeOffer", 1).
+		Set("tradeofferid", "123").
+		Set("language", "english")
+	err := client.Do(ctx, req, &out)
+
+Parameters set with Set go into the URL of GET requests and into the form body of POST requests, parameters set
+with SetQuery always go into the URL. The API-key of the Client is added like a parameter set with Set, so it's
+sent in the body of POST requests and never ends up in logs of proxies.
+*/
+type Request struct {
+	method   string
+	iface    string
+	endpoint urlHelper.VersionedURLEndpoint
+	params   url.Values
+	query    url.Values
+	format   config.OutputFormat
+	err      error
+}
+
+// NewRequest returns a GET request to the version of the method of the interface, answered in JSON
+func NewRequest(iface, method string, version int) *Request {
+	return &Request{
+		method:   http.MethodGet,
+		iface:    iface,
+		endpoint: urlHelper.VersionedURLEndpoint{EndpointPath: method, Version: strconv.Itoa(version)},
+		params:   url.Values{},
+		query:    url.Values{},
+		format:   config.Json,
+	}
+}
+
+// Post makes r a POST request
+func (r *Request) Post() *Request {
+	return r.Method(http.MethodPost)
+}
+
+// Method sets the HTTP method of r, http.MethodGet or http.MethodPost
+func (r *Request) Method(method string) *Request {
+	if method != http.MethodGet && method != http.MethodPost && r.err == nil {
+		r.err = fmt.Errorf("unsupported HTTP method %q, use GET or POST", method)
+	}
+	r.method = method
+	return r
+}
+
+// Format sets the format Steam answers in
+func (r *Request) Format(format config.OutputFormat) *Request {
+	r.format = format
+	return r
+}
+
+// Set sets the parameter name to value, replacing values set before
+func (r *Request) Set(name, value string) *Request {
+	r.params.Set(name, value)
+	return r
+}
+
+// SetList sets the list parameter name to values, sent as name[0], name[1], ...
+func (r *Request) SetList(name string, values []string) *Request {
+	for i, value := range values {
+		r.params.Set(fmt.Sprintf("%s[%d]", name, i), value)
+	}
+	return r
+}
+
+// SetValues sets all parameters of vals
+func (r *Request) SetValues(vals url.Values) *Request {
+	for name, values := range vals {
+		r.params[name] = append([]string(nil), values...)
+	}
+	return r
+}
+
+// SetQuery sets the parameter name to value in the URL, also for POST requests
+func (r *Request) SetQuery(name, value string) *Request {
+	r.query.Set(name, value)
+	return r
+}
+
+// InputJSON sends v encoded as JSON in the input_json parameter, like the service interfaces expect for nested
+// or list parameters
+func (r *Request) InputJSON(v any) *Request {
+	jsonBytes, err := json.Marshal(v)
+	if err != nil {
+		if r.err == nil {
+			r.err = fmt.Errorf("error marshaling JSON: %w", err)
+		}
+		return r
+	}
+	return r.Set("input_json", string(jsonBytes))
+}
+
+// InputProtobuf sends the encoded protobuf message msg in the input_protobuf_encoded parameter
+func (r *Request) InputProtobuf(msg []byte) *Request {
+	return r.Set("input_protobuf_encoded", base64.StdEncoding.EncodeToString(msg))
+}
+
+// Err returns the first error that occurred while building r
+func (r *Request) Err() error {
+	return r.err
+}
+
+// values returns the parameters of r with the key of c and the format added
+func (r *Request) values(c Client) url.Values {
+	vals := url.Values{}
+	for name, values := range r.params {
+		vals[name] = append([]string(nil), values...)
+	}
+	if c.IsKeySet() && !vals.Has("key") {
+		vals.Set("key", c.Key)
+	}
+	if !vals.Has("format") {
+		vals.Set("format", r.format.String())
+	}
+	return vals
+}
+
+/*
+Send sends r and returns the response, whose body has to be closed by the caller. Like the typed methods it returns
+an *APIError for every status code other than 200. GET requests go through the retry policy and cache of c,
+POST requests are sent exactly once.
+*/
+func (c Client) Send(ctx context.Context, r *Request) (*http.Response, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	vals := r.values(c)
+	if r.method == http.MethodPost {
+		return c.post(ctx, r.iface, r.endpoint, r.query, vals)
+	}
+
+	for name, values := range r.query {
+		vals[name] = values
+	}
+	return c.get(ctx, r.iface, r.endpoint, vals)
+}
+
+// Do sends r and decodes the answer into out, see Client.Call. out may be nil to discard the answer.
+func (c Client) Do(ctx context.Context, r *Request, out any) error {
+	if r.err != nil {
+		return r.err
+	}
+	switch r.format {
+	case config.Json, config.Xml, config.Vdf, config.Protobuf:
+		// protobuf_raw is checked by Send
+	default:
+		return unsupportedFormatError(r.format)
+	}
+
+	resp, err := c.Send(ctx, r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		_, err := io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	return decodeUnwrapped(r.format, resp.Body, out)
+}
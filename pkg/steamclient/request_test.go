@@ -0,0 +1,149 @@
+package steamclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestGet(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/IEconService/GetTradeOffer/v1",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, url.Values{
+				"key":          {"test-key"},
+				"format":       {"xml"},
+				"tradeofferid": {"123"},
+				"language":     {"english"},
+				"ids[0]":       {"1"},
+				"ids[1]":       {"2"},
+			}, req.URL.Query())
+			return httpmock.NewStringResponse(200, `<response><offer><tradeofferid>123</tradeofferid></offer></response>`), nil
+		})
+
+	req := NewRequest("IEconService", "GetTradeOffer", 1).
+		Format(config.Xml).
+		Set("tradeofferid", "123").
+		SetQuery("language", "english").
+		SetList("ids", []string{"1", "2"})
+
+	var out struct {
+		TradeOfferId string `xml:"offer>tradeofferid"`
+	}
+	err := New("test-key", &http.Client{}).Do(context.Background(), req, &out)
+	assert.NoError(t, err)
+	assert.Equal(t, "123", out.TradeOfferId)
+}
+
+func TestRequestPost(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	tests := []struct {
+		name      string
+		req       *Request
+		wantQuery url.Values
+		wantForm  url.Values
+	}{
+		{
+			name: "form",
+			req:  NewRequest("IEconService", "DeclineTradeOffer", 1).Post().Set("tradeofferid", "123"),
+			wantForm: url.Values{
+				"key":          {"test-key"},
+				"format":       {"json"},
+				"tradeofferid": {"123"},
+			},
+		},
+		{
+			name: "query",
+			req:  NewRequest("IEconService", "DeclineTradeOffer", 1).Post().Set("tradeofferid", "123").SetQuery("language", "english"),
+			wantQuery: url.Values{
+				"language": {"english"},
+			},
+			wantForm: url.Values{
+				"key":          {"test-key"},
+				"format":       {"json"},
+				"tradeofferid": {"123"},
+			},
+		},
+		{
+			name: "input_json",
+			req:  NewRequest("IEconService", "DeclineTradeOffer", 1).Post().InputJSON(map[string]any{"tradeofferid": "123"}),
+			wantForm: url.Values{
+				"key":        {"test-key"},
+				"format":     {"json"},
+				"input_json": {`{"tradeofferid":"123"}`},
+			},
+		},
+		{
+			name: "input_protobuf_encoded",
+			req:  NewRequest("IEconService", "DeclineTradeOffer", 1).Post().InputProtobuf([]byte{0x08, 0x7b}),
+			wantForm: url.Values{
+				"key":                    {"test-key"},
+				"format":                 {"json"},
+				"input_protobuf_encoded": {"CHs="},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder("POST", "https://api.steampowered.com/IEconService/DeclineTradeOffer/v1",
+				func(req *http.Request) (*http.Response, error) {
+					if tt.wantQuery == nil {
+						assert.Empty(t, req.URL.RawQuery, "the key belongs into the body")
+					} else {
+						assert.Equal(t, tt.wantQuery, req.URL.Query())
+					}
+					assert.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+					body, _ := io.ReadAll(req.Body)
+					form, _ := url.ParseQuery(string(body))
+					assert.Equal(t, tt.wantForm, form)
+					return httpmock.NewStringResponse(200, `{"response":{}}`), nil
+				})
+
+			err := New("test-key", &http.Client{}).Do(context.Background(), tt.req, nil)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRequestErrors(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "https://api.steampowered.com/IEconService/DeclineTradeOffer/v1",
+		httpmock.NewStringResponder(403, "Forbidden"))
+
+	client := New("test-key", &http.Client{})
+
+	req := NewRequest("IEconService", "DeclineTradeOffer", 1).InputJSON(map[string]any{"ch": make(chan int)})
+	assert.Error(t, req.Err())
+	assert.Error(t, client.Do(context.Background(), req, nil))
+
+	req = NewRequest("IEconService", "DeclineTradeOffer", 1).Method(http.MethodPut)
+	_, err := client.Send(context.Background(), req)
+	assert.Error(t, err)
+
+	req = NewRequest("IEconService", "DeclineTradeOffer", 1).Format(config.OutputFormat(42))
+	assert.ErrorIs(t, client.Do(context.Background(), req, nil), ErrUnsupportedFormat)
+	assert.Equal(t, 0, httpmock.GetTotalCallCount(), "invalid requests must not be sent")
+
+	var out json.RawMessage
+	req = NewRequest("IEconService", "DeclineTradeOffer", 1).Post()
+	err = client.Do(context.Background(), req, &out)
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	assert.Equal(t, "DeclineTradeOffer", apiErr.Endpoint)
+}
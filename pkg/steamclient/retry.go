@@ -0,0 +1,144 @@
+package steamclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// Defaults of the RetryPolicy
+const (
+	defaultMaxAttempts = 3
+	defaultBaseDelay   = 500 * time.Millisecond
+	defaultMaxDelay    = 30 * time.Second
+)
+
+/*
+RetryPolicy retries failed requests with exponential backoff.
+
+The delay before retry n is BaseDelay * 2^(n-1), capped at MaxDelay, of which a random half is added as jitter.
+If Steam sent a Retry-After header, the retry waits at least that long. Waits end early with the error of the
+last attempt if the context of the request ends.
+
+Only idempotent requests (GET and HEAD) are retried; POST calls like SetUserStatsForGame are never repeated.
+The zero value uses the defaults.
+*/
+type RetryPolicy struct {
+	MaxAttempts int           // Number of attempts including the first one (default: 3)
+	BaseDelay   time.Duration // Delay before the first retry (default: 500ms)
+	MaxDelay    time.Duration // Upper limit of the backoff delay (default: 30s)
+
+	// Decides if a failed attempt is retried (default: IsRetryable)
+	Retryable func(err error) bool
+
+	// Called before every retry with the number of the failed attempt (starting at 1), its error and the delay
+	// before the next attempt
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts <= 0 {
+		return defaultMaxAttempts
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// backoff returns the delay after the given failed attempt (starting at 1)
+func (p *RetryPolicy) backoff(attempt int, err error) time.Duration {
+	base, maxDelay := p.BaseDelay, p.MaxDelay
+	if base <= 0 {
+		base = defaultBaseDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxDelay
+	}
+
+	delay := maxDelay
+	if shift := attempt - 1; shift < 32 && base<<shift > 0 && base<<shift < maxDelay {
+		delay = base << shift
+	}
+	delay = delay/2 + rand.N(delay/2+1)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > delay {
+		delay = apiErr.RetryAfter
+	}
+	return delay
+}
+
+/*
+IsRetryable reports whether a request that failed with err may succeed if it's sent again.
+
+That's the case for 429 Too Many Requests, 500, 502, 503 and 504 responses, timeouts and connections that were
+refused, reset or closed early. Cancelled contexts, client errors and a used up daily quota are not retryable.
+*/
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isIdempotent reports whether requests with the given method may be sent more than once
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// withRetry calls send until it succeeds, the retry policy gives up or ctx ends
+func (c Client) withRetry(ctx context.Context, method string, send func() (*http.Response, error)) (*http.Response, error) {
+	if c.Retry == nil || !isIdempotent(method) {
+		return send()
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := send()
+		if err == nil || attempt >= c.Retry.maxAttempts() || !c.Retry.retryable(err) {
+			return resp, err
+		}
+
+		delay := c.Retry.backoff(attempt, err)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			// the next attempt would be cancelled anyway
+			return nil, err
+		}
+		if c.Retry.OnRetry != nil {
+			c.Retry.OnRetry(attempt, err, delay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package steamclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "429", err: &APIError{StatusCode: http.StatusTooManyRequests}, want: true},
+		{name: "500", err: &APIError{StatusCode: http.StatusInternalServerError}, want: true},
+		{name: "502", err: &APIError{StatusCode: http.StatusBadGateway}, want: true},
+		{name: "503", err: &APIError{StatusCode: http.StatusServiceUnavailable}, want: true},
+		{name: "504", err: &APIError{StatusCode: http.StatusGatewayTimeout}, want: true},
+		{name: "400", err: &APIError{StatusCode: http.StatusBadRequest}, want: false},
+		{name: "401", err: &APIError{StatusCode: http.StatusUnauthorized}, want: false},
+		{name: "404", err: &APIError{StatusCode: http.StatusNotFound}, want: false},
+		{name: "timeout", err: fmt.Errorf("Get: %w", timeoutError{}), want: true},
+		{name: "connection reset", err: fmt.Errorf("read: %w", syscall.ECONNRESET), want: true},
+		{name: "connection refused", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), want: true},
+		{name: "unexpected EOF", err: io.ErrUnexpectedEOF, want: true},
+		{name: "canceled", err: context.Canceled, want: false},
+		{name: "deadline", err: fmt.Errorf("Get: %w", context.DeadlineExceeded), want: false},
+		{name: "quota", err: ErrDailyQuotaExceeded, want: false},
+		{name: "key", err: ErrKeyRequired, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 5: time.Second, 100: time.Second} {
+		for i := 0; i < 20; i++ {
+			got := p.backoff(attempt, errors.New("failed"))
+			assert.GreaterOrEqual(t, got, want/2, "attempt %d", attempt)
+			assert.LessOrEqual(t, got, want, "attempt %d", attempt)
+		}
+	}
+
+	// Retry-After wins over shorter delays
+	got := p.backoff(1, &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 5 * time.Second})
+	assert.Equal(t, 5*time.Second, got)
+}
+
+func TestClientRetry(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	type retry struct {
+		attempt int
+		status  int
+	}
+
+	tests := []struct {
+		name        string
+		statuses    []int
+		maxAttempts int
+		wantCalls   int
+		wantRetries []retry
+		wantStatus  int // status code of the returned *APIError, 0 for success
+	}{
+		{
+			name:        "success after retries",
+			statuses:    []int{503, 429, 200},
+			wantCalls:   3,
+			wantRetries: []retry{{1, 503}, {2, 429}},
+		},
+		{
+			name:        "attempts exhausted",
+			statuses:    []int{500, 500, 500, 500},
+			maxAttempts: 2,
+			wantCalls:   2,
+			wantRetries: []retry{{1, 500}},
+			wantStatus:  500,
+		},
+		{
+			name:       "not retryable",
+			statuses:   []int{404, 200},
+			wantCalls:  1,
+			wantStatus: 404,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+			calls := 0
+			httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamNews/GetNewsForApp/v2",
+				func(req *http.Request) (*http.Response, error) {
+					status := tt.statuses[calls]
+					calls++
+					return httpmock.NewStringResponse(status, `{"appnews":{"appid":440}}`), nil
+				})
+
+			var retries []retry
+			client := New("test-key", &http.Client{})
+			client.Retry = &RetryPolicy{
+				MaxAttempts: tt.maxAttempts,
+				BaseDelay:   time.Millisecond,
+				OnRetry: func(attempt int, err error, delay time.Duration) {
+					var apiErr *APIError
+					if assert.ErrorAs(t, err, &apiErr) {
+						retries = append(retries, retry{attempt, apiErr.StatusCode})
+					}
+				},
+			}
+
+			got, err := client.GetNewsForApp(GetNewsForAppParams{AppId: 440, Format: config.Json})
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Equal(t, tt.wantRetries, retries)
+			if tt.wantStatus != 0 {
+				var apiErr *APIError
+				if assert.ErrorAs(t, err, &apiErr) {
+					assert.Equal(t, tt.wantStatus, apiErr.StatusCode)
+				}
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(440), got.AppId)
+			}
+		})
+	}
+}
+
+func TestClientRetryRespectsContext(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamNews/GetNewsForApp/v2",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(http.StatusTooManyRequests, "")
+			resp.Header.Set("Retry-After", "60")
+			return resp, nil
+		})
+
+	client := New("test-key", &http.Client{})
+	client.Retry = &RetryPolicy{MaxAttempts: 5}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err := client.GetNewsForAppCtx(ctx, GetNewsForAppParams{AppId: 440, Format: config.Json})
+
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Less(t, time.Since(start), time.Second, "the retry should not wait past the deadline")
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestRetryOnlyIdempotent(t *testing.T) {
+	client := Client{Retry: &RetryPolicy{BaseDelay: time.Millisecond}}
+
+	for method, wantCalls := range map[string]int{http.MethodGet: 3, http.MethodPost: 1} {
+		calls := 0
+		_, err := client.withRetry(context.Background(), method, func() (*http.Response, error) {
+			calls++
+			return nil, &APIError{StatusCode: http.StatusServiceUnavailable}
+		})
+		assert.Error(t, err)
+		assert.Equal(t, wantCalls, calls, method)
+	}
+}
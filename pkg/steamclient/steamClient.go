@@ -1,17 +1,40 @@
 package steamclient
 
 import (
+	"context"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	urlHelper "github.com/xemkayx/steam-api/internal/urlHelper"
 )
 
 /*
 This Client is used to send the requests to steam
 */
 type Client struct {
-	Key        string       // Access-/API-Key for the Steam API
-	HttpClient *http.Client // An Http-Client to send requests with. Customizable
+	Key         string       // Access-/API-Key for the Steam API
+	HttpClient  *http.Client // An Http-Client to send requests with. Customizable
+	RateLimiter *RateLimiter // Optional, throttles the requests. Can be shared between Clients
+	Retry       *RetryPolicy // Optional, retries failed GET requests
+
+	Cache     Cache                    // Optional, caches successful GET responses
+	CacheTTLs map[string]time.Duration // Overrides DefaultCacheTTLs, keyed by "Interface/Endpoint"
+
+	BaseURL        string // Base URL of the Web API, e.g. a caching proxy (default: constant.SteamWebApiBaseURL)
+	PublisherKey   bool   // Key is a publisher key, so publisher-only methods are sent to the partner host
+	PartnerBaseURL string // Base URL of the partner host (default: constant.SteamPartnerApiBaseURL)
+
+	UserAgent string       // Optional, User-Agent header of all requests
+	Logger    *slog.Logger // Logger for the requests (default: slog.Default())
+
+	middlewares []Middleware      // see Use
+	transport   http.RoundTripper // transport of HttpClient wrapped in the middlewares, built by Use
+	flights     *flightGroup      // coalesces cached GET requests, see cachedGet
 }
 
 // Create a Client, without Key
@@ -19,7 +42,7 @@ func NewClientWithoutKey(httpClient *http.Client) *Client {
 	if httpClient == nil {
 		httpClient = &http.Client{}
 	}
-	return &Client{HttpClient: httpClient}
+	return &Client{HttpClient: httpClient, flights: &flightGroup{}}
 }
 
 /*
@@ -29,18 +52,107 @@ func New(key string, httpClient *http.Client) *Client {
 	if httpClient == nil {
 		httpClient = &http.Client{}
 	}
-	return &Client{Key: key, HttpClient: httpClient}
+	return &Client{Key: key, HttpClient: httpClient, flights: &flightGroup{}}
 }
 
 func (c Client) IsKeySet() bool {
 	return c.Key != ""
 }
 
-// General method to send a GET request
-func (c Client) getRequest(urlStr string) (resp *http.Response, err error) {
+func (c Client) logger() *slog.Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+	return slog.Default()
+}
+
+// General method to send a request. The values of form are sent as the body of POST requests.
+// The request is bound to ctx, so cancelling ctx aborts it and the returned error wraps ctx.Err().
+func (c Client) request(ctx context.Context, method, urlStr string, form url.Values) (resp *http.Response, err error) {
 	if c.HttpClient == nil {
 		return nil, errors.New("the HttpClient should is not defined")
 	}
-	slog.Debug("Sending GET-Request to " + urlStr)
-	return c.HttpClient.Get(urlStr)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if c.RateLimiter != nil {
+		if err := c.RateLimiter.Wait(ctx, c.Key); err != nil {
+			return nil, err
+		}
+	}
+
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, urlStr, body)
+	if err != nil {
+		return nil, err
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	// the key is left out of the log
+	c.logger().DebugContext(ctx, "Sending "+method+"-Request", "url", cacheKey(urlStr))
+	return c.httpClient().Do(req)
+}
+
+// Sends a GET request to the given endpoint and returns an *APIError for every status code other than 200.
+// Failed requests are retried according to c.Retry, successful ones are cached in c.Cache.
+func (c Client) get(ctx context.Context, interf string, endpoint urlHelper.VersionedURLEndpoint, vals url.Values) (*http.Response, error) {
+	return c.getCacheable(ctx, interf, endpoint, vals, nil)
+}
+
+// getCacheable is like get, but only caches responses whose body cacheable accepts. Some endpoints answer failures
+// like an unknown vanity URL with 200; they would otherwise be repeated from the cache for the whole TTL.
+func (c Client) getCacheable(ctx context.Context, interf string, endpoint urlHelper.VersionedURLEndpoint, vals url.Values, cacheable func(body []byte) bool) (*http.Response, error) {
+	if err := checkFormat(interf, vals.Get("format")); err != nil {
+		return nil, err
+	}
+	urlStr := urlHelper.RequestURLFormatterWithBase(c.baseURL(ctx, interf, endpoint.EndpointPath), interf, endpoint, vals)
+	fetch := func(ctx context.Context) (*http.Response, error) {
+		return c.send(ctx, http.MethodGet, interf, endpoint, urlStr, nil)
+	}
+
+	if c.Cache != nil {
+		if ttl := c.cacheTTL(ctx, interf, endpoint.EndpointPath); ttl > 0 {
+			return c.cachedGet(ctx, urlStr, ttl, fetch, cacheable)
+		}
+	}
+	return fetch(ctx)
+}
+
+// Sends a POST request with vals as form body and query in the URL to the given endpoint and returns an *APIError
+// for every status code other than 200. POST requests are neither retried nor cached.
+func (c Client) post(ctx context.Context, interf string, endpoint urlHelper.VersionedURLEndpoint, query, vals url.Values) (*http.Response, error) {
+	if err := checkFormat(interf, vals.Get("format")); err != nil {
+		return nil, err
+	}
+	urlStr := strings.TrimSuffix(urlHelper.RequestURLFormatterWithBase(c.baseURL(ctx, interf, endpoint.EndpointPath), interf, endpoint, query), "?")
+	return c.send(ctx, http.MethodPost, interf, endpoint, urlStr, vals)
+}
+
+// send sends a request to urlStr, retrying it according to c.Retry
+func (c Client) send(ctx context.Context, method, interf string, endpoint urlHelper.VersionedURLEndpoint, urlStr string, form url.Values) (*http.Response, error) {
+	return c.withRetry(ctx, method, func() (*http.Response, error) {
+		resp, err := c.request(ctx, method, urlStr, form)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkResponse(resp, interf, endpoint); err != nil {
+			var apiErr *APIError
+			if c.RateLimiter != nil && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests {
+				pause := apiErr.RetryAfter
+				if pause <= 0 {
+					pause = defaultThrottlePause
+				}
+				c.RateLimiter.Pause(c.Key, pause)
+			}
+			return nil, err
+		}
+		return resp, nil
+	})
 }
@@ -1,8 +1,15 @@
 package steamclient
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"testing"
+	"time"
+
+	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+
+	"github.com/jarcoal/httpmock"
 )
 
 func TestNewClientWithoutId(t *testing.T) {
@@ -30,3 +37,43 @@ func TestNew(t *testing.T) {
 		t.Errorf("Expected IsKeySet to return true for non-empty key")
 	}
 }
+
+func TestRequestWithCancelledContext(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamNews/GetNewsForApp/v2",
+		httpmock.NewStringResponder(200, `{"appnews":{"appid":440}}`))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClientWithoutKey(&http.Client{})
+	got, err := client.GetNewsForAppCtx(ctx, GetNewsForAppParams{AppId: 440, Format: config.Json})
+	if got != nil {
+		t.Errorf("Expected no result for a cancelled context, got %v", got)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected error to wrap context.Canceled, got %v", err)
+	}
+}
+
+func TestRequestWithDeadline(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.steampowered.com/ISteamNews/GetNewsForApp/v2",
+		func(req *http.Request) (*http.Response, error) {
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	client := New("test-key", &http.Client{})
+	_, err := client.GetNewsForAppCtx(ctx, GetNewsForAppParams{AppId: 440, Format: config.Json})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected error to wrap context.DeadlineExceeded, got %v", err)
+	}
+}
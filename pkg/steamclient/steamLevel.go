@@ -0,0 +1,41 @@
+package steamclient
+
+// xpPerLevel is the XP a level of the first ten costs; every further ten levels cost that much more
+const xpPerLevel = 100
+
+/*
+XPForLevel returns the total XP needed to reach level on Steam's level curve: every level of the first ten costs
+100 XP, every level of the next ten 200 XP and so on. The XP values of GetBadges follow it:
+
+	badges, err := client.GetBadges(steamclient.GetBadgesParams{SteamId: id})
+	steamclient.LevelForXP(badges.PlayerXP)    // badges.PlayerLevel
+	steamclient.XPForLevel(badges.PlayerLevel) // badges.PlayerXPNeededCurrentLevel
+	steamclient.XPToNextLevel(badges.PlayerXP) // badges.PlayerXPNeededToLevelUp
+*/
+func XPForLevel(level int) int {
+	if level <= 0 {
+		return 0
+	}
+	blocks, rest := level/10, level%10
+	return xpPerLevel*10*blocks*(blocks+1)/2 + xpPerLevel*(blocks+1)*rest
+}
+
+// LevelForXP returns the level a player with xp XP has reached
+func LevelForXP(xp int) int {
+	if xp <= 0 {
+		return 0
+	}
+	level := 0
+	for cost := xpPerLevel; ; cost += xpPerLevel {
+		if xp < 10*cost {
+			return level + xp/cost
+		}
+		xp -= 10 * cost
+		level += 10
+	}
+}
+
+// XPToNextLevel returns the XP a player with xp XP still needs for the next level
+func XPToNextLevel(xp int) int {
+	return XPForLevel(LevelForXP(xp)+1) - max(xp, 0)
+}
@@ -0,0 +1,42 @@
+package steamclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSteamLevel(t *testing.T) {
+	tests := []struct {
+		level int
+		xp    int
+	}{
+		{level: 0, xp: 0},
+		{level: 1, xp: 100},
+		{level: 10, xp: 1000},
+		{level: 11, xp: 1200},
+		{level: 20, xp: 3000},
+		{level: 21, xp: 3300},
+		{level: 42, xp: 11000},
+		{level: 100, xp: 55000},
+		{level: 5000, xp: 125250000},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.xp, XPForLevel(tt.level), "XP for level %d", tt.level)
+		assert.Equal(t, tt.level, LevelForXP(tt.xp), "level for %d XP", tt.xp)
+		if tt.level > 0 {
+			assert.Equal(t, tt.level-1, LevelForXP(tt.xp-1), "level for %d XP", tt.xp-1)
+		}
+	}
+
+	for level := 0; level < 300; level++ {
+		xp := XPForLevel(level)
+		assert.Equal(t, XPForLevel(level+1)-xp, XPToNextLevel(xp))
+		assert.Equal(t, 1, XPToNextLevel(XPForLevel(level+1)-1))
+	}
+
+	assert.Equal(t, 0, XPForLevel(-1))
+	assert.Equal(t, 0, LevelForXP(-100))
+	assert.Equal(t, 100, XPToNextLevel(-100))
+}
@@ -3,10 +3,10 @@ package steamclient
 import (
 	"encoding/json"
 	"encoding/xml"
-	"fmt"
 	"io"
 
 	"github.com/xemkayx/steam-api/pkg/steamclient/config"
+	"github.com/xemkayx/steam-api/pkg/vdf"
 )
 
 const apiKeyErrorMessage = "you have to specify an API-key to call this endpoint"
@@ -25,8 +25,14 @@ func decodeResponse(format config.OutputFormat, body io.ReadCloser, result inter
 		}
 		return result, nil
 
+	case config.Vdf:
+		if _, err := decodeVDF(&result, body); err != nil {
+			return nil, err
+		}
+		return result, nil
+
 	default:
-		return nil, fmt.Errorf("unsupported format requested: %v", format)
+		return nil, unsupportedFormatError(format)
 	}
 }
 
@@ -48,3 +54,23 @@ func decodeXML[T any](dest *T, r io.Reader) (*T, error) {
 	xmlDecoder := xml.NewDecoder(r)
 	return decode(dest, r, xmlDecoder.Decode)
 }
+
+// decodeVDF decodes KeyValues text from a reader into the destination object dest.
+func decodeVDF[T any](dest *T, r io.Reader) (*T, error) {
+	vdfDecoder := vdf.NewDecoder(r)
+	return decode(dest, r, vdfDecoder.Decode)
+}
+
+// protoUnmarshaler is implemented by the protobuf messages of the model packages
+type protoUnmarshaler interface {
+	UnmarshalProto(b []byte) error
+}
+
+// decodeProtobuf decodes a binary protobuf message from a reader into the destination object dest.
+func decodeProtobuf(dest protoUnmarshaler, r io.Reader) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return dest.UnmarshalProto(b)
+}
@@ -0,0 +1 @@
+K!HELLDIVERS™ 2 *(c3dff088e090f81d6e3d88eabbb67732647c69cf@x
\ No newline at end of file
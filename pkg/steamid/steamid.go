@@ -0,0 +1,385 @@
+/*
+Package steamid implements SteamIDs and their textual representations.
+
+A SteamID is a 64-bit number that combines the universe, account type, instance and account id of an account:
+
+	76561197960287930  SteamID64
+	STEAM_0:0:11101    Steam2 (individual accounts only)
+	[U:1:22202]        Steam3
+	22202              AccountID
+*/
+package steamid
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Universe is the Steam universe an account belongs to
+type Universe uint8
+
+const (
+	UniverseInvalid  Universe = 0
+	UniversePublic   Universe = 1
+	UniverseBeta     Universe = 2
+	UniverseInternal Universe = 3
+	UniverseDev      Universe = 4
+)
+
+func (u Universe) String() string {
+	switch u {
+	case UniverseInvalid:
+		return "Invalid"
+	case UniversePublic:
+		return "Public"
+	case UniverseBeta:
+		return "Beta"
+	case UniverseInternal:
+		return "Internal"
+	case UniverseDev:
+		return "Dev"
+	default:
+		return "Unknown Universe"
+	}
+}
+
+// AccountType is the type of a Steam account
+type AccountType uint8
+
+const (
+	AccountTypeInvalid        AccountType = 0
+	AccountTypeIndividual     AccountType = 1
+	AccountTypeMultiseat      AccountType = 2
+	AccountTypeGameServer     AccountType = 3
+	AccountTypeAnonGameServer AccountType = 4
+	AccountTypePending        AccountType = 5
+	AccountTypeContentServer  AccountType = 6
+	AccountTypeClan           AccountType = 7
+	AccountTypeChat           AccountType = 8
+	AccountTypeP2PSuperSeeder AccountType = 9
+	AccountTypeAnonUser       AccountType = 10
+)
+
+func (t AccountType) String() string {
+	switch t {
+	case AccountTypeInvalid:
+		return "Invalid"
+	case AccountTypeIndividual:
+		return "Individual"
+	case AccountTypeMultiseat:
+		return "Multiseat"
+	case AccountTypeGameServer:
+		return "GameServer"
+	case AccountTypeAnonGameServer:
+		return "AnonGameServer"
+	case AccountTypePending:
+		return "Pending"
+	case AccountTypeContentServer:
+		return "ContentServer"
+	case AccountTypeClan:
+		return "Clan"
+	case AccountTypeChat:
+		return "Chat"
+	case AccountTypeP2PSuperSeeder:
+		return "P2PSuperSeeder"
+	case AccountTypeAnonUser:
+		return "AnonUser"
+	default:
+		return "Unknown AccountType"
+	}
+}
+
+// Instances of individual accounts
+const (
+	InstanceAll     uint32 = 0
+	InstanceDesktop uint32 = 1
+	InstanceConsole uint32 = 2
+	InstanceWeb     uint32 = 4
+)
+
+// Instance flags of chat accounts
+const (
+	chatInstanceFlagClan  uint32 = 0x80000
+	chatInstanceFlagLobby uint32 = 0x40000
+)
+
+// bit layout of a SteamID64
+const (
+	accountIDMask   = 0xFFFFFFFF
+	instanceShift   = 32
+	instanceMask    = 0xFFFFF
+	accountTypeMask = 0xF
+	typeShift       = 52
+	universeShift   = 56
+)
+
+// ErrInvalid is returned when a string is not a SteamID in any of the supported formats
+var ErrInvalid = errors.New("invalid SteamID")
+
+// ID is a 64-bit SteamID. Its zero value is the invalid SteamID.
+type ID uint64
+
+// New creates a SteamID from its parts
+func New(universe Universe, accountType AccountType, instance uint32, accountID uint32) ID {
+	return ID(uint64(universe)<<universeShift |
+		uint64(accountType&accountTypeMask)<<typeShift |
+		uint64(instance&instanceMask)<<instanceShift |
+		uint64(accountID))
+}
+
+// FromAccountID creates the SteamID of an individual account in the public universe
+func FromAccountID(accountID uint32) ID {
+	return New(UniversePublic, AccountTypeIndividual, InstanceDesktop, accountID)
+}
+
+// AccountID returns the 32-bit account id, which is also used by e.g. Dota 2 and CS2 match data
+func (id ID) AccountID() uint32 {
+	return uint32(id & accountIDMask)
+}
+
+// Instance returns the instance of the account
+func (id ID) Instance() uint32 {
+	return uint32(id>>instanceShift) & instanceMask
+}
+
+// AccountType returns the type of the account
+func (id ID) AccountType() AccountType {
+	return AccountType(id>>typeShift) & accountTypeMask
+}
+
+// Universe returns the universe of the account
+func (id ID) Universe() Universe {
+	return Universe(id >> universeShift)
+}
+
+// Uint64 returns the SteamID64 as a number
+func (id ID) Uint64() uint64 {
+	return uint64(id)
+}
+
+/*
+IsValid reports whether the SteamID is a plausible SteamID.
+
+The rules are the same the Steam client uses: the universe and account type have to be known,
+and individual, clan and game server accounts need an account id and a matching instance.
+*/
+func (id ID) IsValid() bool {
+	if id.Universe() <= UniverseInvalid || id.Universe() > UniverseDev {
+		return false
+	}
+
+	switch id.AccountType() {
+	case AccountTypeInvalid:
+		return false
+	case AccountTypeIndividual:
+		return id.AccountID() != 0 && id.Instance() <= InstanceWeb
+	case AccountTypeClan:
+		return id.AccountID() != 0 && id.Instance() == 0
+	case AccountTypeGameServer:
+		return id.AccountID() != 0
+	default:
+		return id.AccountType() <= AccountTypeAnonUser
+	}
+}
+
+// String returns the SteamID64 in its decimal form
+func (id ID) String() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+/*
+Steam2 returns the SteamID in the legacy STEAM_X:Y:Z format.
+
+Only individual accounts have a Steam2 form; the universe is written as 0 for the public universe,
+like the Source engine does. An empty string is returned for all other account types.
+*/
+func (id ID) Steam2() string {
+	if id.AccountType() != AccountTypeIndividual {
+		return ""
+	}
+	universe := id.Universe()
+	if universe == UniversePublic {
+		universe = UniverseInvalid
+	}
+	return fmt.Sprintf("STEAM_%d:%d:%d", universe, id.AccountID()&1, id.AccountID()>>1)
+}
+
+// Steam3 returns the SteamID in the [U:1:N] format
+func (id ID) Steam3() string {
+	letter := steam3Letter(id)
+	instance := id.Instance()
+
+	// only anonymous game servers and multiseat accounts carry their instance
+	if id.AccountType() == AccountTypeAnonGameServer || id.AccountType() == AccountTypeMultiseat {
+		return fmt.Sprintf("[%c:%d:%d:%d]", letter, id.Universe(), id.AccountID(), instance)
+	}
+	return fmt.Sprintf("[%c:%d:%d]", letter, id.Universe(), id.AccountID())
+}
+
+var steam3Letters = map[AccountType]rune{
+	AccountTypeInvalid:        'I',
+	AccountTypeIndividual:     'U',
+	AccountTypeMultiseat:      'M',
+	AccountTypeGameServer:     'G',
+	AccountTypeAnonGameServer: 'A',
+	AccountTypePending:        'P',
+	AccountTypeContentServer:  'C',
+	AccountTypeClan:           'g',
+	AccountTypeChat:           'T',
+	AccountTypeAnonUser:       'a',
+}
+
+func steam3Letter(id ID) rune {
+	if id.AccountType() == AccountTypeChat {
+		switch {
+		case id.Instance()&chatInstanceFlagClan != 0:
+			return 'c'
+		case id.Instance()&chatInstanceFlagLobby != 0:
+			return 'L'
+		}
+	}
+	if l, ok := steam3Letters[id.AccountType()]; ok {
+		return l
+	}
+	return 'i'
+}
+
+var (
+	steam2Regex = regexp.MustCompile(`^STEAM_([0-5]):([01]):(\d+)$`)
+	steam3Regex = regexp.MustCompile(`^\[([a-zA-Z]):([0-5]):(\d+)(?::(\d+))?\]$`)
+)
+
+/*
+Parse parses a SteamID in any of the supported formats:
+
+  - SteamID64, e.g. 76561197960287930
+  - Steam2, e.g. STEAM_0:0:11101
+  - Steam3, e.g. [U:1:22202]
+  - AccountID, e.g. 22202 (any decimal number below 2^32, taken as an individual account in the public universe)
+
+ErrInvalid is returned if s is in none of these formats or the result is not a valid SteamID.
+*/
+func Parse(s string) (ID, error) {
+	s = strings.TrimSpace(s)
+
+	id, err := parse(s)
+	if err != nil {
+		return 0, err
+	}
+	if !id.IsValid() {
+		return 0, fmt.Errorf("%w: %q", ErrInvalid, s)
+	}
+	return id, nil
+}
+
+func parse(s string) (ID, error) {
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		if n <= accountIDMask {
+			return FromAccountID(uint32(n)), nil
+		}
+		return ID(n), nil
+	}
+
+	if m := steam2Regex.FindStringSubmatch(s); m != nil {
+		universe, _ := strconv.ParseUint(m[1], 10, 8)
+		y, _ := strconv.ParseUint(m[2], 10, 32)
+		z, err := strconv.ParseUint(m[3], 10, 31)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalid, s)
+		}
+		if universe == uint64(UniverseInvalid) {
+			universe = uint64(UniversePublic)
+		}
+		return New(Universe(universe), AccountTypeIndividual, InstanceDesktop, uint32(z<<1|y)), nil
+	}
+
+	if m := steam3Regex.FindStringSubmatch(s); m != nil {
+		return parseSteam3(s, m)
+	}
+
+	return 0, fmt.Errorf("%w: %q", ErrInvalid, s)
+}
+
+func parseSteam3(s string, m []string) (ID, error) {
+	letter := rune(m[1][0])
+	universe, _ := strconv.ParseUint(m[2], 10, 8)
+	accountID, err := strconv.ParseUint(m[3], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalid, s)
+	}
+
+	var accountType AccountType
+	var instance uint32
+	switch letter {
+	case 'U':
+		accountType, instance = AccountTypeIndividual, InstanceDesktop
+	case 'c':
+		accountType, instance = AccountTypeChat, chatInstanceFlagClan
+	case 'L':
+		accountType, instance = AccountTypeChat, chatInstanceFlagLobby
+	default:
+		found := false
+		for t, l := range steam3Letters {
+			if l == letter {
+				accountType, found = t, true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("%w: %q", ErrInvalid, s)
+		}
+	}
+
+	if m[4] != "" {
+		inst, err := strconv.ParseUint(m[4], 10, 20)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalid, s)
+		}
+		instance = uint32(inst)
+	}
+	return New(Universe(universe), accountType, instance, uint32(accountID)), nil
+}
+
+// MarshalText returns the SteamID64 in its decimal form, as the Steam API does
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+/*
+UnmarshalText parses a SteamID from text.
+
+Decimal numbers are always taken as SteamID64, since that's what the Steam API returns; all other formats
+are parsed with Parse. Empty text results in the zero ID.
+*/
+func (id *ID) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if s == "" {
+		*id = 0
+		return nil
+	}
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		*id = ID(n)
+		return nil
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// UnmarshalJSON accepts SteamIDs as JSON strings (like the Steam API returns them) as well as JSON numbers
+func (id *ID) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	return id.UnmarshalText([]byte(s))
+}
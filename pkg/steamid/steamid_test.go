@@ -0,0 +1,103 @@
+package steamid
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	const robin ID = 76561197960435530
+
+	tests := []struct {
+		name  string
+		input string
+		want  ID
+	}{
+		{name: "SteamID64", input: "76561197960435530", want: robin},
+		{name: "Steam2 universe 0", input: "STEAM_0:0:84901", want: robin},
+		{name: "Steam2 universe 1", input: "STEAM_1:0:84901", want: robin},
+		{name: "Steam3", input: "[U:1:169802]", want: robin},
+		{name: "AccountID", input: "169802", want: robin},
+		{name: "whitespace", input: "  76561197960435530\n", want: robin},
+		{name: "clan", input: "[g:1:4]", want: 103582791429521412},
+		{name: "anon game server", input: "[A:1:123:456]", want: New(UniversePublic, AccountTypeAnonGameServer, 456, 123)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{"", "abc", "STEAM_0:2:1", "[X:1:1]", "[U:1:0]", "0", "103582791429521412x", "18446744073709551615"} {
+		_, err := Parse(input)
+		assert.True(t, errors.Is(err, ErrInvalid), "Parse(%q) error = %v", input, err)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	id := ID(76561197960435530)
+
+	assert.Equal(t, "76561197960435530", id.String())
+	assert.Equal(t, "STEAM_0:0:84901", id.Steam2())
+	assert.Equal(t, "[U:1:169802]", id.Steam3())
+	assert.Equal(t, uint32(169802), id.AccountID())
+	assert.Equal(t, uint64(76561197960435530), id.Uint64())
+	assert.Equal(t, UniversePublic, id.Universe())
+	assert.Equal(t, AccountTypeIndividual, id.AccountType())
+	assert.Equal(t, InstanceDesktop, id.Instance())
+	assert.Equal(t, id, FromAccountID(169802))
+
+	clan := ID(103582791429521412)
+	assert.Equal(t, "", clan.Steam2())
+	assert.Equal(t, "[g:1:4]", clan.Steam3())
+	assert.Equal(t, AccountTypeClan, clan.AccountType())
+	assert.Equal(t, "Clan", clan.AccountType().String())
+
+	assert.Equal(t, "[A:1:123:456]", New(UniversePublic, AccountTypeAnonGameServer, 456, 123).Steam3())
+	assert.Equal(t, "[L:1:5]", New(UniversePublic, AccountTypeChat, chatInstanceFlagLobby, 5).Steam3())
+}
+
+func TestIsValid(t *testing.T) {
+	assert.True(t, ID(76561197960435530).IsValid())
+	assert.False(t, ID(0).IsValid())
+	assert.False(t, New(UniversePublic, AccountTypeIndividual, InstanceDesktop, 0).IsValid())
+	assert.False(t, New(UniversePublic, AccountTypeIndividual, 5, 1).IsValid())
+	assert.False(t, New(UniversePublic, AccountTypeClan, 1, 1).IsValid())
+	assert.False(t, New(Universe(9), AccountTypeIndividual, InstanceDesktop, 1).IsValid())
+}
+
+func TestTextMarshalling(t *testing.T) {
+	type player struct {
+		SteamID ID `json:"steamid" xml:"steamid"`
+	}
+
+	var fromString, fromNumber player
+	assert.NoError(t, json.Unmarshal([]byte(`{"steamid":"76561197960435530"}`), &fromString))
+	assert.NoError(t, json.Unmarshal([]byte(`{"steamid":76561197960435530}`), &fromNumber))
+	assert.Equal(t, ID(76561197960435530), fromString.SteamID)
+	assert.Equal(t, fromString, fromNumber)
+
+	data, err := json.Marshal(fromString)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"steamid":"76561197960435530"}`, string(data))
+
+	var fromXML player
+	assert.NoError(t, xml.Unmarshal([]byte(`<player><steamid>[U:1:169802]</steamid></player>`), &fromXML))
+	assert.Equal(t, fromString, fromXML)
+
+	// decimal numbers in API responses are never AccountIDs
+	var small ID
+	assert.NoError(t, small.UnmarshalText([]byte("123456789")))
+	assert.Equal(t, ID(123456789), small)
+
+	assert.Error(t, small.UnmarshalText([]byte("not an id")))
+}
@@ -0,0 +1,210 @@
+package vdf
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// magic numbers of the appinfo.vdf and packageinfo.vdf versions that can be read
+const (
+	appInfoMagic27     uint32 = 0x07564427
+	appInfoMagic28     uint32 = 0x07564428
+	appInfoMagic29     uint32 = 0x07564429
+	packageInfoMagic27 uint32 = 0x06565527
+	packageInfoMagic28 uint32 = 0x06565528
+)
+
+// AppInfoFile is the content of Steam's appcache/appinfo.vdf
+type AppInfoFile struct {
+	Version  uint32 // format version, 27 to 29
+	Universe uint32 // Steam universe, 1 for public
+	Apps     []AppInfo
+}
+
+// AppInfo is a single app of appinfo.vdf
+type AppInfo struct {
+	AppID        uint32
+	InfoState    uint32
+	LastUpdated  time.Time
+	PICSToken    uint64
+	TextSHA1     [20]byte // SHA-1 of the text form of Data
+	ChangeNumber uint32
+	BinarySHA1   [20]byte // SHA-1 of the binary form of Data (version 28 and later)
+	Data         *Node    // the app's KeyValues, usually a single "appinfo" key
+}
+
+// PackageInfoFile is the content of Steam's appcache/packageinfo.vdf
+type PackageInfoFile struct {
+	Version  uint32 // format version, 27 or 28
+	Universe uint32 // Steam universe, 1 for public
+	Packages []PackageInfo
+}
+
+// PackageInfo is a single package of packageinfo.vdf
+type PackageInfo struct {
+	PackageID    uint32
+	SHA1         [20]byte
+	ChangeNumber uint32
+	PICSToken    uint64 // version 28 and later
+	Data         *Node  // the package's KeyValues, usually a single key named after the package id
+}
+
+/*
+ParseAppInfo reads Steam's appcache/appinfo.vdf (versions 27, 28 and 29).
+
+Version 29 stores the keys in a string table at the end of the file, so the whole file is read into memory.
+*/
+func ParseAppInfo(r io.Reader) (*AppInfoFile, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &binaryReader{r: bufio.NewReader(bytes.NewReader(data))}
+	var header [8]byte
+	if err := b.read(header[:]); err != nil {
+		return nil, err
+	}
+	magic := binary.LittleEndian.Uint32(header[:4])
+
+	file := &AppInfoFile{Universe: binary.LittleEndian.Uint32(header[4:])}
+	switch magic {
+	case appInfoMagic27:
+		file.Version = 27
+	case appInfoMagic28:
+		file.Version = 28
+	case appInfoMagic29:
+		file.Version = 29
+		keys, err := readStringTable(b, data)
+		if err != nil {
+			return nil, err
+		}
+		b.keys = keys
+	default:
+		return nil, b.errorf("unknown appinfo.vdf magic 0x%08x", magic)
+	}
+
+	for {
+		var appID [4]byte
+		if err := b.read(appID[:]); err != nil {
+			return nil, err
+		}
+		app := AppInfo{AppID: binary.LittleEndian.Uint32(appID[:])}
+		if app.AppID == 0 {
+			return file, nil
+		}
+
+		// size, info state, last updated, pics token, text sha1, change number
+		var rec [4 + 4 + 4 + 8 + 20 + 4]byte
+		if err := b.read(rec[:]); err != nil {
+			return nil, err
+		}
+		app.InfoState = binary.LittleEndian.Uint32(rec[4:])
+		app.LastUpdated = time.Unix(int64(binary.LittleEndian.Uint32(rec[8:])), 0)
+		app.PICSToken = binary.LittleEndian.Uint64(rec[12:])
+		copy(app.TextSHA1[:], rec[20:40])
+		app.ChangeNumber = binary.LittleEndian.Uint32(rec[40:])
+
+		if file.Version >= 28 {
+			if err := b.read(app.BinarySHA1[:]); err != nil {
+				return nil, err
+			}
+		}
+
+		root, err := parseBinary(b)
+		if err != nil {
+			return nil, fmt.Errorf("app %d: %w", app.AppID, err)
+		}
+		app.Data = root
+		file.Apps = append(file.Apps, app)
+	}
+}
+
+// readStringTable reads the key table of appinfo.vdf v29, whose offset follows the header
+func readStringTable(b *binaryReader, data []byte) ([]string, error) {
+	var off [8]byte
+	if err := b.read(off[:]); err != nil {
+		return nil, err
+	}
+	offset := int64(binary.LittleEndian.Uint64(off[:]))
+	if offset < 0 || offset+4 > int64(len(data)) {
+		return nil, b.errorf("string table offset %d is out of range", offset)
+	}
+
+	t := &binaryReader{r: bufio.NewReader(bytes.NewReader(data[offset:])), offset: offset}
+	var count [4]byte
+	if err := t.read(count[:]); err != nil {
+		return nil, err
+	}
+	n := binary.LittleEndian.Uint32(count[:])
+	if int64(n) > int64(len(data)) {
+		return nil, t.errorf("string table count %d is too large", n)
+	}
+
+	keys := make([]string, 0, n)
+	for i := uint32(0); i < n; i++ {
+		s, err := t.readCString()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, s)
+	}
+	return keys, nil
+}
+
+// ParsePackageInfo reads Steam's appcache/packageinfo.vdf (versions 27 and 28)
+func ParsePackageInfo(r io.Reader) (*PackageInfoFile, error) {
+	b := &binaryReader{r: bufio.NewReader(r)}
+	var header [8]byte
+	if err := b.read(header[:]); err != nil {
+		return nil, err
+	}
+	magic := binary.LittleEndian.Uint32(header[:4])
+
+	file := &PackageInfoFile{Universe: binary.LittleEndian.Uint32(header[4:])}
+	switch magic {
+	case packageInfoMagic27:
+		file.Version = 27
+	case packageInfoMagic28:
+		file.Version = 28
+	default:
+		return nil, b.errorf("unknown packageinfo.vdf magic 0x%08x", magic)
+	}
+
+	for {
+		var id [4]byte
+		if err := b.read(id[:]); err != nil {
+			return nil, err
+		}
+		pkg := PackageInfo{PackageID: binary.LittleEndian.Uint32(id[:])}
+		if pkg.PackageID == 0xFFFFFFFF {
+			return file, nil
+		}
+
+		var rec [20 + 4]byte
+		if err := b.read(rec[:]); err != nil {
+			return nil, err
+		}
+		copy(pkg.SHA1[:], rec[:20])
+		pkg.ChangeNumber = binary.LittleEndian.Uint32(rec[20:])
+
+		if file.Version >= 28 {
+			var token [8]byte
+			if err := b.read(token[:]); err != nil {
+				return nil, err
+			}
+			pkg.PICSToken = binary.LittleEndian.Uint64(token[:])
+		}
+
+		root, err := parseBinary(b)
+		if err != nil {
+			return nil, fmt.Errorf("package %d: %w", pkg.PackageID, err)
+		}
+		pkg.Data = root
+		file.Packages = append(file.Packages, pkg)
+	}
+}
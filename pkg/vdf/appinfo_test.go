@@ -0,0 +1,146 @@
+package vdf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// appInfoFixture builds an appinfo.vdf with a single app (440) in the given version
+func appInfoFixture(t *testing.T, magic uint32) []byte {
+	t.Helper()
+	le := binary.LittleEndian
+
+	kv, err := MarshalBinary(NewObject("", NewObject("appinfo",
+		NewValue("appid", "440"),
+		NewObject("common", NewValue("name", "Team Fortress 2")),
+	)))
+	assert.NoError(t, err)
+
+	var keys []string
+	if magic == appInfoMagic29 {
+		// replace the C string keys by indices into the string table
+		keys = []string{"appinfo", "appid", "common", "name"}
+		for i, key := range keys {
+			idx := make([]byte, 4)
+			le.PutUint32(idx, uint32(i))
+			kv = bytes.Replace(kv, []byte("\x00"+key+"\x00"), append([]byte{0}, idx...), 1)
+			kv = bytes.Replace(kv, []byte("\x01"+key+"\x00"), append([]byte{1}, idx...), 1)
+		}
+	}
+
+	var rec bytes.Buffer
+	binary.Write(&rec, le, uint32(2))          // info state
+	binary.Write(&rec, le, uint32(1700000000)) // last updated
+	binary.Write(&rec, le, uint64(99))         // pics token
+	rec.Write(bytes.Repeat([]byte{0xAA}, 20))  // text sha1
+	binary.Write(&rec, le, uint32(12345))      // change number
+	if magic != appInfoMagic27 {
+		rec.Write(bytes.Repeat([]byte{0xBB}, 20)) // binary sha1
+	}
+	rec.Write(kv)
+
+	var buf bytes.Buffer
+	binary.Write(&buf, le, magic)
+	binary.Write(&buf, le, uint32(1))
+	tableOffsetPos := buf.Len()
+	if magic == appInfoMagic29 {
+		binary.Write(&buf, le, uint64(0))
+	}
+	binary.Write(&buf, le, uint32(440))
+	binary.Write(&buf, le, uint32(rec.Len()))
+	buf.Write(rec.Bytes())
+	binary.Write(&buf, le, uint32(0))
+
+	data := buf.Bytes()
+	if magic == appInfoMagic29 {
+		le.PutUint64(data[tableOffsetPos:], uint64(len(data)))
+		table := le.AppendUint32(nil, uint32(len(keys)))
+		for _, key := range keys {
+			table = append(append(table, key...), 0)
+		}
+		data = append(data, table...)
+	}
+	return data
+}
+
+func TestParseAppInfo(t *testing.T) {
+	for _, tt := range []struct {
+		magic   uint32
+		version uint32
+	}{
+		{appInfoMagic27, 27},
+		{appInfoMagic28, 28},
+		{appInfoMagic29, 29},
+	} {
+		file, err := ParseAppInfo(bytes.NewReader(appInfoFixture(t, tt.magic)))
+		if !assert.NoError(t, err, "version %d", tt.version) {
+			continue
+		}
+
+		assert.Equal(t, tt.version, file.Version)
+		assert.Equal(t, uint32(1), file.Universe)
+		if !assert.Len(t, file.Apps, 1) {
+			continue
+		}
+
+		app := file.Apps[0]
+		assert.Equal(t, uint32(440), app.AppID)
+		assert.Equal(t, uint32(2), app.InfoState)
+		assert.Equal(t, time.Unix(1700000000, 0), app.LastUpdated)
+		assert.Equal(t, uint64(99), app.PICSToken)
+		assert.Equal(t, uint32(12345), app.ChangeNumber)
+		assert.Equal(t, byte(0xAA), app.TextSHA1[0])
+		if tt.version >= 28 {
+			assert.Equal(t, byte(0xBB), app.BinarySHA1[0])
+		}
+
+		var info struct {
+			AppInfo struct {
+				AppID  uint32 `vdf:"appid"`
+				Common struct {
+					Name string `vdf:"name"`
+				} `vdf:"common"`
+			} `vdf:"appinfo"`
+		}
+		assert.NoError(t, app.Data.Decode(&info))
+		assert.Equal(t, uint32(440), info.AppInfo.AppID)
+		assert.Equal(t, "Team Fortress 2", info.AppInfo.Common.Name)
+	}
+}
+
+func TestParsePackageInfo(t *testing.T) {
+	le := binary.LittleEndian
+	kv, err := MarshalBinary(map[string]interface{}{
+		"0": map[string]interface{}{"packageid": int32(0), "appids": []int32{7}},
+	})
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	binary.Write(&buf, le, packageInfoMagic28)
+	binary.Write(&buf, le, uint32(1))
+	binary.Write(&buf, le, uint32(0))         // package id
+	buf.Write(bytes.Repeat([]byte{0xCC}, 20)) // sha1
+	binary.Write(&buf, le, uint32(77))        // change number
+	binary.Write(&buf, le, uint64(5))         // pics token
+	buf.Write(kv)
+	binary.Write(&buf, le, uint32(0xFFFFFFFF))
+
+	file, err := ParsePackageInfo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(28), file.Version)
+	if assert.Len(t, file.Packages, 1) {
+		pkg := file.Packages[0]
+		assert.Equal(t, uint32(77), pkg.ChangeNumber)
+		assert.Equal(t, uint64(5), pkg.PICSToken)
+		assert.Equal(t, "7", pkg.Data.Find("0", "appids", "0").Value)
+	}
+}
+
+func TestParseAppInfoUnknownMagic(t *testing.T) {
+	_, err := ParseAppInfo(bytes.NewReader([]byte{1, 2, 3, 4, 1, 0, 0, 0}))
+	assert.Error(t, err)
+}
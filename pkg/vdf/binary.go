@@ -0,0 +1,370 @@
+package vdf
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"unicode/utf16"
+)
+
+// type bytes of binary KeyValues
+const (
+	binObject     byte = 0x00
+	binString     byte = 0x01
+	binInt32      byte = 0x02
+	binFloat32    byte = 0x03
+	binPointer    byte = 0x04
+	binWideString byte = 0x05
+	binColor      byte = 0x06
+	binUint64     byte = 0x07
+	binEnd        byte = 0x08
+	binInt64      byte = 0x0A
+	binEndAlt     byte = 0x0B // alternative end marker, used by some old files
+)
+
+var typeToBinary = map[Type]byte{
+	String:     binString,
+	Int32:      binInt32,
+	Float32:    binFloat32,
+	Pointer:    binPointer,
+	WideString: binWideString,
+	Color:      binColor,
+	Uint64:     binUint64,
+	Int64:      binInt64,
+}
+
+// BinaryFormatError describes malformed binary KeyValues data
+type BinaryFormatError struct {
+	Offset int64  // offset in the input the error was found at
+	Msg    string // description of the error
+}
+
+func (e *BinaryFormatError) Error() string {
+	return fmt.Sprintf("vdf: invalid binary data at offset %d: %s", e.Offset, e.Msg)
+}
+
+// binaryReader reads binary KeyValues and keeps track of the offset for error messages
+type binaryReader struct {
+	r      *bufio.Reader
+	offset int64
+	keys   []string // string table for keys, used by appinfo.vdf v29
+}
+
+func (b *binaryReader) errorf(format string, args ...interface{}) error {
+	return &BinaryFormatError{Offset: b.offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (b *binaryReader) readByte() (byte, error) {
+	c, err := b.r.ReadByte()
+	if err != nil {
+		return 0, b.eof(err)
+	}
+	b.offset++
+	return c, nil
+}
+
+func (b *binaryReader) read(p []byte) error {
+	n, err := io.ReadFull(b.r, p)
+	b.offset += int64(n)
+	if err != nil {
+		return b.eof(err)
+	}
+	return nil
+}
+
+func (b *binaryReader) eof(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return b.errorf("unexpected end of data")
+	}
+	return err
+}
+
+func (b *binaryReader) readCString() (string, error) {
+	s, err := b.r.ReadString(0)
+	b.offset += int64(len(s))
+	if err != nil {
+		return "", b.eof(err)
+	}
+	return s[:len(s)-1], nil
+}
+
+func (b *binaryReader) readWideString() (string, error) {
+	var units []uint16
+	buf := make([]byte, 2)
+	for {
+		if err := b.read(buf); err != nil {
+			return "", err
+		}
+		u := binary.LittleEndian.Uint16(buf)
+		if u == 0 {
+			return string(utf16.Decode(units)), nil
+		}
+		units = append(units, u)
+	}
+}
+
+func (b *binaryReader) readKey() (string, error) {
+	if b.keys == nil {
+		return b.readCString()
+	}
+	buf := make([]byte, 4)
+	if err := b.read(buf); err != nil {
+		return "", err
+	}
+	idx := binary.LittleEndian.Uint32(buf)
+	if int(idx) >= len(b.keys) {
+		return "", b.errorf("key index %d is out of range of the string table", idx)
+	}
+	return b.keys[idx], nil
+}
+
+// readObject reads child nodes into parent up to and including the end marker
+func (b *binaryReader) readObject(parent *Node) error {
+	for {
+		typ, err := b.readByte()
+		if err != nil {
+			return err
+		}
+		if typ == binEnd || typ == binEndAlt {
+			return nil
+		}
+
+		key, err := b.readKey()
+		if err != nil {
+			return err
+		}
+
+		if typ == binObject {
+			child := NewObject(key)
+			if err := b.readObject(child); err != nil {
+				return err
+			}
+			parent.Append(child)
+			continue
+		}
+
+		child, err := b.readValue(typ, key)
+		if err != nil {
+			return err
+		}
+		parent.Append(child)
+	}
+}
+
+func (b *binaryReader) readValue(typ byte, key string) (*Node, error) {
+	n := NewValue(key, "")
+	buf := make([]byte, 8)
+
+	switch typ {
+	case binString:
+		s, err := b.readCString()
+		if err != nil {
+			return nil, err
+		}
+		n.Value, n.Type = s, String
+	case binWideString:
+		s, err := b.readWideString()
+		if err != nil {
+			return nil, err
+		}
+		n.Value, n.Type = s, WideString
+	case binInt32, binPointer, binColor:
+		if err := b.read(buf[:4]); err != nil {
+			return nil, err
+		}
+		n.Value = strconv.FormatInt(int64(int32(binary.LittleEndian.Uint32(buf))), 10)
+		n.Type = map[byte]Type{binInt32: Int32, binPointer: Pointer, binColor: Color}[typ]
+	case binFloat32:
+		if err := b.read(buf[:4]); err != nil {
+			return nil, err
+		}
+		f := math.Float32frombits(binary.LittleEndian.Uint32(buf))
+		n.Value, n.Type = strconv.FormatFloat(float64(f), 'f', -1, 32), Float32
+	case binUint64:
+		if err := b.read(buf); err != nil {
+			return nil, err
+		}
+		n.Value, n.Type = strconv.FormatUint(binary.LittleEndian.Uint64(buf), 10), Uint64
+	case binInt64:
+		if err := b.read(buf); err != nil {
+			return nil, err
+		}
+		n.Value, n.Type = strconv.FormatInt(int64(binary.LittleEndian.Uint64(buf)), 10), Int64
+	default:
+		return nil, b.errorf("unknown type 0x%02x of key %q", typ, key)
+	}
+	return n, nil
+}
+
+/*
+ParseBinary reads a binary KeyValues document from r, as found in shortcuts.vdf.
+
+Like Parse, it returns a root node with an empty key holding the top-level keys. Values keep their binary Type,
+numbers are stored in their decimal form.
+*/
+func ParseBinary(r io.Reader) (*Node, error) {
+	return parseBinary(&binaryReader{r: bufio.NewReader(r)})
+}
+
+func parseBinary(b *binaryReader) (*Node, error) {
+	root := NewObject("")
+	if err := b.readObject(root); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// UnmarshalBinary parses the binary KeyValues in data and stores the result in the value pointed to by v.
+// The mapping rules are the same as for Unmarshal.
+func UnmarshalBinary(data []byte, v interface{}) error {
+	return NewBinaryDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// A BinaryDecoder reads and decodes binary KeyValues from an input stream
+type BinaryDecoder struct {
+	b *binaryReader
+}
+
+// NewBinaryDecoder returns a new decoder that reads binary KeyValues from r
+func NewBinaryDecoder(r io.Reader) *BinaryDecoder {
+	return &BinaryDecoder{b: &binaryReader{r: bufio.NewReader(r)}}
+}
+
+// Decode reads the next binary KeyValues document (up to its end marker) and stores it in the value pointed to by v.
+// Several documents can be read from the same stream by calling Decode repeatedly.
+func (d *BinaryDecoder) Decode(v interface{}) error {
+	root, err := parseBinary(d.b)
+	if err != nil {
+		return err
+	}
+	return root.Decode(v)
+}
+
+/*
+MarshalBinary returns the binary KeyValues encoding of v, as used by shortcuts.vdf.
+
+The mapping rules are the same as for Marshal. Strings are written as String, booleans and integers up to 32 bits
+(including uint32, which is stored bit for bit) as Int32, int64 as Int64, uint64 as Uint64 and floats as Float32.
+The Type of a Node is kept.
+*/
+func MarshalBinary(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := NewBinaryEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// A BinaryEncoder writes binary KeyValues to an output stream
+type BinaryEncoder struct {
+	w io.Writer
+}
+
+// NewBinaryEncoder returns a new encoder that writes binary KeyValues to w
+func NewBinaryEncoder(w io.Writer) *BinaryEncoder {
+	return &BinaryEncoder{w: w}
+}
+
+// Encode writes v as a binary KeyValues document, terminated by an end marker
+func (e *BinaryEncoder) Encode(v interface{}) error {
+	root, err := NewNode(v)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if root.Key == "" && root.IsObject() {
+		for _, c := range root.Children {
+			if err := writeBinaryNode(&buf, c); err != nil {
+				return err
+			}
+		}
+	} else if err := writeBinaryNode(&buf, root); err != nil {
+		return err
+	}
+	buf.WriteByte(binEnd)
+
+	_, err = e.w.Write(buf.Bytes())
+	return err
+}
+
+func writeBinaryNode(buf *bytes.Buffer, n *Node) error {
+	if n.IsObject() {
+		buf.WriteByte(binObject)
+		writeCString(buf, n.Key)
+		for _, c := range n.Children {
+			if err := writeBinaryNode(buf, c); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(binEnd)
+		return nil
+	}
+
+	typ, ok := typeToBinary[n.Type]
+	if !ok {
+		return fmt.Errorf("vdf: key %q has unknown type %d", n.Key, n.Type)
+	}
+	buf.WriteByte(typ)
+	writeCString(buf, n.Key)
+
+	var tmp [8]byte
+	switch n.Type {
+	case String:
+		writeCString(buf, n.Value)
+	case WideString:
+		for _, u := range utf16.Encode([]rune(n.Value)) {
+			binary.LittleEndian.PutUint16(tmp[:2], u)
+			buf.Write(tmp[:2])
+		}
+		buf.Write([]byte{0, 0})
+	case Int32, Pointer, Color:
+		u, err := parse32(n.Value)
+		if err != nil {
+			return fmt.Errorf("vdf: key %q: %w", n.Key, err)
+		}
+		binary.LittleEndian.PutUint32(tmp[:4], u)
+		buf.Write(tmp[:4])
+	case Float32:
+		f, err := strconv.ParseFloat(n.Value, 32)
+		if err != nil {
+			return fmt.Errorf("vdf: key %q: %w", n.Key, err)
+		}
+		binary.LittleEndian.PutUint32(tmp[:4], math.Float32bits(float32(f)))
+		buf.Write(tmp[:4])
+	case Uint64:
+		u, err := strconv.ParseUint(n.Value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("vdf: key %q: %w", n.Key, err)
+		}
+		binary.LittleEndian.PutUint64(tmp[:], u)
+		buf.Write(tmp[:])
+	case Int64:
+		i, err := strconv.ParseInt(n.Value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("vdf: key %q: %w", n.Key, err)
+		}
+		binary.LittleEndian.PutUint64(tmp[:], uint64(i))
+		buf.Write(tmp[:])
+	}
+	return nil
+}
+
+// parse32 parses a signed or unsigned 32-bit number into its bits
+func parse32(s string) (uint32, error) {
+	if i, err := strconv.ParseInt(s, 10, 32); err == nil {
+		return uint32(int32(i)), nil
+	}
+	u, err := strconv.ParseUint(s, 10, 32)
+	return uint32(u), err
+}
+
+func writeCString(buf *bytes.Buffer, s string) {
+	buf.WriteString(s)
+	buf.WriteByte(0)
+}
@@ -0,0 +1,131 @@
+package vdf
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testShortcut struct {
+	AppID        uint32   `vdf:"appid"`
+	AppName      string   `vdf:"AppName"`
+	Exe          string   `vdf:"Exe"`
+	IsHidden     bool     `vdf:"IsHidden"`
+	LastPlayTime int32    `vdf:"LastPlayTime"`
+	Tags         []string `vdf:"tags"`
+}
+
+type testShortcuts struct {
+	Shortcuts []testShortcut `vdf:"shortcuts"`
+}
+
+// a shortcuts.vdf with a single non-Steam game, as written by the Steam client
+var shortcutsFixture = []byte("" +
+	"\x00shortcuts\x00" +
+	"\x000\x00" +
+	"\x02appid\x00\x00\x5e\xd0\xb2" + // 3000000000 stored as int32
+	"\x01AppName\x00My Game\x00" +
+	"\x01Exe\x00\"C:\\Games\\game.exe\"\x00" +
+	"\x02IsHidden\x00\x00\x00\x00\x00" +
+	"\x02LastPlayTime\x00\x10\x27\x00\x00" +
+	"\x00tags\x00" +
+	"\x010\x00favorite\x00" +
+	"\x08" +
+	"\x08" +
+	"\x08" +
+	"\x08")
+
+func TestParseBinary(t *testing.T) {
+	root, err := ParseBinary(bytes.NewReader(shortcutsFixture))
+	assert.NoError(t, err)
+
+	game := root.Find("shortcuts", "0")
+	if assert.NotNil(t, game) {
+		assert.Equal(t, &Node{Key: "appid", Value: "-1294967296", Type: Int32}, game.Child("appid"))
+		assert.Equal(t, &Node{Key: "AppName", Value: "My Game", Type: String}, game.Child("appname"))
+		assert.Equal(t, "favorite", game.Find("tags", "0").Value)
+	}
+}
+
+func TestUnmarshalBinary(t *testing.T) {
+	var got testShortcuts
+	assert.NoError(t, UnmarshalBinary(shortcutsFixture, &got))
+
+	want := testShortcuts{
+		Shortcuts: []testShortcut{
+			{
+				AppID:        3000000000,
+				AppName:      "My Game",
+				Exe:          `"C:\Games\game.exe"`,
+				IsHidden:     false,
+				LastPlayTime: 10000,
+				Tags:         []string{"favorite"},
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+
+	// writing the struct again gives the same bytes as the Steam client
+	data, err := MarshalBinary(got)
+	assert.NoError(t, err)
+	assert.Equal(t, shortcutsFixture, data)
+}
+
+func TestBinaryNodeRoundTrip(t *testing.T) {
+	root := NewObject("",
+		NewObject("root",
+			&Node{Key: "float", Value: "1.5", Type: Float32},
+			&Node{Key: "wide", Value: "wïde ✓", Type: WideString},
+			&Node{Key: "color", Value: "-1", Type: Color},
+			&Node{Key: "pointer", Value: "42", Type: Pointer},
+			&Node{Key: "uint64", Value: "76561197960435530", Type: Uint64},
+			&Node{Key: "int64", Value: "-5", Type: Int64},
+			NewObject("empty"),
+		),
+	)
+
+	var buf bytes.Buffer
+	assert.NoError(t, NewBinaryEncoder(&buf).Encode(root))
+
+	got, err := ParseBinary(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, root, got)
+}
+
+func TestBinaryDecoderMultipleDocuments(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewBinaryEncoder(&buf)
+	assert.NoError(t, enc.Encode(map[string]string{"a": "1"}))
+	assert.NoError(t, enc.Encode(map[string]string{"a": "2"}))
+
+	dec := NewBinaryDecoder(&buf)
+	for _, want := range []string{"1", "2"} {
+		var got map[string]string
+		assert.NoError(t, dec.Decode(&got))
+		assert.Equal(t, want, got["a"])
+	}
+}
+
+func TestParseBinaryErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		offset int64
+	}{
+		{name: "truncated", input: "\x00shortcuts\x00\x02appid\x00\x01\x02", offset: 20},
+		{name: "unknown type", input: "\x09key\x00", offset: 5},
+		{name: "missing end", input: "\x01key\x00value\x00", offset: 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBinary(bytes.NewReader([]byte(tt.input)))
+			var formatErr *BinaryFormatError
+			if assert.True(t, errors.As(err, &formatErr), "expected *BinaryFormatError, got %v", err) {
+				assert.Equal(t, tt.offset, formatErr.Offset)
+			}
+		})
+	}
+}
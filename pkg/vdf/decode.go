@@ -0,0 +1,332 @@
+package vdf
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// UnmarshalTypeError describes a value that can't be stored in the Go type it is mapped to
+type UnmarshalTypeError struct {
+	Key   string       // key of the node that failed
+	Value string       // description of the value, e.g. "object" or the string value
+	Type  reflect.Type // Go type the value was decoded into
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	return fmt.Sprintf("vdf: cannot unmarshal %s of key %q into Go value of type %s", e.Value, e.Key, e.Type)
+}
+
+// InvalidUnmarshalError is returned for arguments to Unmarshal that are no non-nil pointers
+type InvalidUnmarshalError struct {
+	Type reflect.Type
+}
+
+func (e *InvalidUnmarshalError) Error() string {
+	if e.Type == nil {
+		return "vdf: Unmarshal(nil)"
+	}
+	return "vdf: Unmarshal(non-pointer " + e.Type.String() + ")"
+}
+
+var (
+	nodeType            = reflect.TypeOf(Node{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+/*
+Unmarshal parses the KeyValues text in data and stores the result in the value pointed to by v.
+
+The top-level keys of the document are mapped onto v, so a document starting with "response" is decoded
+into a struct with a field tagged `json:"response"` (or `vdf:"response"`), just like the JSON output of the Steam API.
+Lists are read from objects with the keys "0", "1", ... or from repeated keys. Keys without a matching field are ignored.
+If v is a *Node, it receives the parsed tree.
+*/
+func Unmarshal(data []byte, v interface{}) error {
+	return NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// A Decoder reads and decodes KeyValues text from an input stream
+type Decoder struct {
+	r io.Reader
+}
+
+// NewDecoder returns a new decoder that reads from r
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// Decode reads the whole KeyValues document from its input and stores it in the value pointed to by v.
+// See Unmarshal for the mapping rules.
+func (d *Decoder) Decode(v interface{}) error {
+	root, err := Parse(d.r)
+	if err != nil {
+		return err
+	}
+	return root.Decode(v)
+}
+
+// Decode stores the node in the value pointed to by v, using the same rules as Unmarshal.
+// For an object node, its children are mapped onto v.
+func (n *Node) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &InvalidUnmarshalError{Type: reflect.TypeOf(v)}
+	}
+	return decodeValue(n, rv.Elem())
+}
+
+func decodeValue(n *Node, v reflect.Value) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeValue(n, v.Elem())
+	}
+
+	if v.Type() == nodeType {
+		v.Set(reflect.ValueOf(*n))
+		return nil
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		if n.IsObject() {
+			return typeError(n, v)
+		}
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(n.Value)); err != nil {
+			return fmt.Errorf("vdf: key %q: %w", n.Key, err)
+		}
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if !n.IsObject() {
+			return typeError(n, v)
+		}
+		return decodeStruct(n, v)
+
+	case reflect.Map:
+		if !n.IsObject() {
+			return typeError(n, v)
+		}
+		return decodeMap(n, v)
+
+	case reflect.Slice:
+		if !n.IsObject() {
+			// a single value for a list field
+			return appendElement(n, v)
+		}
+		v.Set(v.Slice(0, 0))
+		for _, c := range listElements(n) {
+			if err := appendElement(c, v); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Interface:
+		if !v.IsNil() && v.Elem().Kind() == reflect.Pointer && !v.Elem().IsNil() {
+			// like encoding/json, decode into the pointer held by the interface
+			return decodeValue(n, v.Elem())
+		}
+		if v.NumMethod() != 0 {
+			return typeError(n, v)
+		}
+		v.Set(reflect.ValueOf(n.generic()))
+		return nil
+	}
+
+	if n.IsObject() {
+		return typeError(n, v)
+	}
+	return setScalar(n, v)
+}
+
+func decodeStruct(n *Node, v reflect.Value) error {
+	fields := cachedFields(v.Type())
+	// list fields that already got elements from this object, so repeated keys are collected
+	seen := map[*field]bool{}
+
+	for _, c := range n.Children {
+		f := lookupField(fields, c.Key)
+		if f == nil {
+			continue
+		}
+		fv := fieldByIndex(v, f.index)
+
+		if fv.Kind() != reflect.Slice || hasTextUnmarshaler(fv) {
+			if err := decodeValue(c, fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !seen[f] {
+			seen[f] = true
+			fv.Set(fv.Slice(0, 0))
+		}
+		for _, e := range listElements(c) {
+			if err := appendElement(e, fv); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func decodeMap(n *Node, v reflect.Value) error {
+	t := v.Type()
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(t))
+	}
+	for _, c := range n.Children {
+		key := reflect.New(t.Key()).Elem()
+		if err := setScalar(NewValue(c.Key, c.Key), key); err != nil {
+			return err
+		}
+		elem := reflect.New(t.Elem()).Elem()
+		if err := decodeValue(c, elem); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+	}
+	return nil
+}
+
+// appendElement decodes n as a new element of the slice v
+func appendElement(n *Node, v reflect.Value) error {
+	elem := reflect.New(v.Type().Elem()).Elem()
+	if err := decodeValue(n, elem); err != nil {
+		return err
+	}
+	v.Set(reflect.Append(v, elem))
+	return nil
+}
+
+// isList reports whether the children of n are numbered "0", "1", ... like the Steam API lists
+func isList(n *Node) bool {
+	if len(n.Children) == 0 {
+		return true
+	}
+	for _, c := range n.Children {
+		if _, err := strconv.ParseUint(c.Key, 10, 64); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// listElements returns the elements n contributes to a list: the children of a numbered list object,
+// or n itself for a single value or a repeated key
+func listElements(n *Node) []*Node {
+	if n.IsObject() && isList(n) {
+		return n.Children
+	}
+	return []*Node{n}
+}
+
+func hasTextUnmarshaler(v reflect.Value) bool {
+	return v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType)
+}
+
+func setScalar(n *Node, v reflect.Value) error {
+	s := strings.TrimSpace(n.Value)
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(n.Value)
+		return nil
+
+	case reflect.Bool:
+		switch strings.ToLower(s) {
+		case "", "0", "false":
+			v.SetBool(false)
+		case "1", "true":
+			v.SetBool(true)
+		default:
+			return typeError(n, v)
+		}
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if s == "" {
+			v.SetInt(0)
+			return nil
+		}
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return typeError(n, v)
+		}
+		v.SetInt(i)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if s == "" {
+			v.SetUint(0)
+			return nil
+		}
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			// binary KeyValues store unsigned 32-bit values like appids as Int32
+			i, ierr := strconv.ParseInt(s, 10, 32)
+			if ierr != nil || i >= 0 || v.Type().Bits() != 32 {
+				return typeError(n, v)
+			}
+			u = uint64(uint32(int32(i)))
+		}
+		v.SetUint(u)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		if s == "" {
+			v.SetFloat(0)
+			return nil
+		}
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return typeError(n, v)
+		}
+		v.SetFloat(f)
+		return nil
+	}
+	return typeError(n, v)
+}
+
+// generic converts the node to a string or a map[string]interface{} for interface{} targets
+func (n *Node) generic() interface{} {
+	if !n.IsObject() {
+		return n.Value
+	}
+	m := make(map[string]interface{}, len(n.Children))
+	for _, c := range n.Children {
+		m[c.Key] = c.generic()
+	}
+	return m
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but allocates nil embedded pointers
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+func typeError(n *Node, v reflect.Value) error {
+	desc := "object"
+	if !n.IsObject() {
+		desc = strconv.Quote(n.Value)
+	}
+	return &UnmarshalTypeError{Key: n.Key, Value: desc, Type: v.Type()}
+}
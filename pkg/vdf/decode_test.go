@@ -0,0 +1,158 @@
+package vdf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPlayer struct {
+	SteamID     string  `json:"steamid"`
+	PersonaName string  `json:"personaname"`
+	TimeCreated int64   `json:"timecreated"`
+	LocCityId   *int    `json:"loccityid,omitempty"`
+	Visible     bool    `json:"visible"`
+	Percent     float64 `json:"percent"`
+	Ignored     string  `json:"-"`
+}
+
+type testPlayersWrapper struct {
+	Response struct {
+		Players []testPlayer `json:"players"`
+	} `json:"response"`
+}
+
+// upperText implements encoding.TextUnmarshaler
+type upperText string
+
+func (u *upperText) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		return errors.New("empty")
+	}
+	*u = upperText(strings.ToUpper(string(text)))
+	return nil
+}
+
+func TestUnmarshalSteamResponse(t *testing.T) {
+	input := `"response"
+{
+	"players"
+	{
+		"0"
+		{
+			"steamid"		"76561197960435530"
+			"personaname"		"Robin"
+			"timecreated"		"1063407589"
+			"loccityid"		"3961"
+			"visible"		"1"
+			"percent"		"56.78"
+			"Ignored"		"x"
+		}
+		"1"
+		{
+			"steamid"		"76561197960265731"
+			"visible"		"false"
+		}
+	}
+}`
+
+	var got testPlayersWrapper
+	assert.NoError(t, Unmarshal([]byte(input), &got))
+
+	cityId := 3961
+	assert.Equal(t, []testPlayer{
+		{
+			SteamID:     "76561197960435530",
+			PersonaName: "Robin",
+			TimeCreated: 1063407589,
+			LocCityId:   &cityId,
+			Visible:     true,
+			Percent:     56.78,
+		},
+		{
+			SteamID: "76561197960265731",
+		},
+	}, got.Response.Players)
+}
+
+func TestUnmarshalMappingRules(t *testing.T) {
+	type inner struct {
+		Value string
+	}
+	type embedded struct {
+		Embedded string `vdf:"embedded"`
+	}
+	type doc struct {
+		embedded
+		Tagged   string            `vdf:"vdf_name" json:"json_name"`
+		Repeated []inner           `vdf:"item"`
+		Numbers  []uint32          `json:"numbers"`
+		Single   []string          `json:"single"`
+		Map      map[string]int    `json:"map"`
+		Any      interface{}       `json:"any"`
+		Text     upperText         `json:"text"`
+		Nested   map[string]*inner `json:"nested"`
+	}
+
+	input := `"root"
+{
+	"EMBEDDED"	"e"
+	"vdf_name"	"tagged"
+	"item"	{ "value" "a" }
+	"item"	{ "value" "b" }
+	"numbers"	{ "0" "5" "1" "7" }
+	"single"	"only"
+	"map"	{ "x" "1" "y" "2" }
+	"any"	{ "k" "v" }
+	"text"	"abc"
+	"nested"	{ "n" { "value" "deep" } }
+}`
+
+	var got struct {
+		Root doc `json:"root"`
+	}
+	assert.NoError(t, Unmarshal([]byte(input), &got))
+
+	assert.Equal(t, doc{
+		embedded: embedded{Embedded: "e"},
+		Tagged:   "tagged",
+		Repeated: []inner{{Value: "a"}, {Value: "b"}},
+		Numbers:  []uint32{5, 7},
+		Single:   []string{"only"},
+		Map:      map[string]int{"x": 1, "y": 2},
+		Any:      map[string]interface{}{"k": "v"},
+		Text:     "ABC",
+		Nested:   map[string]*inner{"n": {Value: "deep"}},
+	}, got.Root)
+}
+
+func TestUnmarshalNode(t *testing.T) {
+	var root Node
+	assert.NoError(t, NewDecoder(strings.NewReader(`"a" { "b" "c" }`)).Decode(&root))
+	assert.Equal(t, "c", root.Find("a", "b").Value)
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	var number struct {
+		N int `json:"n"`
+	}
+	err := Unmarshal([]byte(`"n" "abc"`), &number)
+	var typeErr *UnmarshalTypeError
+	if assert.True(t, errors.As(err, &typeErr), "expected *UnmarshalTypeError, got %v", err) {
+		assert.Equal(t, "n", typeErr.Key)
+	}
+
+	err = Unmarshal([]byte(`"n" { }`), &number)
+	assert.True(t, errors.As(err, &typeErr), "expected *UnmarshalTypeError, got %v", err)
+
+	var text struct {
+		T upperText `json:"t"`
+	}
+	assert.Error(t, Unmarshal([]byte(`"t" ""`), &text))
+
+	var invalid *InvalidUnmarshalError
+	assert.True(t, errors.As(Unmarshal([]byte(`"n" "1"`), number), &invalid))
+	assert.True(t, errors.As(Unmarshal([]byte(`"n" "1"`), nil), &invalid))
+}
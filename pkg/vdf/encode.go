@@ -0,0 +1,291 @@
+package vdf
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// UnsupportedTypeError is returned by Marshal for values that can't be represented in KeyValues
+type UnsupportedTypeError struct {
+	Type reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return "vdf: unsupported type: " + e.Type.String()
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+/*
+Marshal returns the KeyValues text of v, indented with tabs like the files written by Steam.
+
+The fields of v become the top-level keys of the document, so Marshal is the counterpart of Unmarshal.
+Keys are named like in Unmarshal and fields with the omitempty option are skipped if they are empty.
+Lists are written as objects with the keys "0", "1", ..., maps with their keys in sorted order.
+Booleans are written as "1" and "0". Nil pointers, slices, maps and interfaces are left out.
+A Node (or *Node) is written as it is; a root node with an empty key is written as its children.
+*/
+func Marshal(v interface{}) ([]byte, error) {
+	return MarshalIndent(v, "", "\t")
+}
+
+// MarshalIndent is like Marshal, but every line begins with prefix and nested keys are indented with indent
+func MarshalIndent(v interface{}, prefix, indent string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	enc.SetIndent(prefix, indent)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// An Encoder writes KeyValues text to an output stream
+type Encoder struct {
+	w      io.Writer
+	prefix string
+	indent string
+}
+
+// NewEncoder returns a new encoder that writes to w, indenting with tabs
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w, indent: "\t"}
+}
+
+// SetIndent sets the prefix of every line and the indentation of nested keys
+func (e *Encoder) SetIndent(prefix, indent string) {
+	e.prefix = prefix
+	e.indent = indent
+}
+
+// Encode writes the KeyValues text of v to the stream, followed by a newline.
+// See Marshal for the mapping rules.
+func (e *Encoder) Encode(v interface{}) error {
+	root, err := NewNode(v)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if root.Key == "" && root.IsObject() {
+		for _, c := range root.Children {
+			e.writeNode(&buf, c, 0)
+		}
+	} else {
+		e.writeNode(&buf, root, 0)
+	}
+	_, err = e.w.Write(buf.Bytes())
+	return err
+}
+
+func (e *Encoder) writeNode(buf *bytes.Buffer, n *Node, depth int) {
+	ind := e.prefix + strings.Repeat(e.indent, depth)
+	buf.WriteString(ind)
+	writeQuoted(buf, n.Key)
+
+	if !n.IsObject() {
+		buf.WriteString("\t\t")
+		writeQuoted(buf, n.Value)
+		buf.WriteByte('\n')
+		return
+	}
+
+	buf.WriteByte('\n')
+	buf.WriteString(ind + "{\n")
+	for _, c := range n.Children {
+		e.writeNode(buf, c, depth+1)
+	}
+	buf.WriteString(ind + "}\n")
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+
+func writeQuoted(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	buf.WriteString(escaper.Replace(s))
+	buf.WriteByte('"')
+}
+
+/*
+NewNode converts v into a KeyValues tree, using the same rules as Marshal.
+
+Structs and maps become a root node with an empty key, holding one child per field or map entry.
+*/
+func NewNode(v interface{}) (*Node, error) {
+	n, err := encodeValue("", reflect.ValueOf(v))
+	if err != nil {
+		return nil, err
+	}
+	if n == nil {
+		return NewObject(""), nil
+	}
+	return n, nil
+}
+
+// encodeValue converts v into a node named key. It returns nil for values that are left out.
+func encodeValue(key string, v reflect.Value) (*Node, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type() == reflect.PointerTo(nodeType) {
+			return withKey(v.Interface().(*Node), key), nil
+		}
+		if v.Kind() == reflect.Pointer && v.Type().Implements(textMarshalerType) {
+			return encodeText(key, v)
+		}
+		return encodeValue(key, v.Elem())
+	}
+
+	if v.Type() == nodeType {
+		n := v.Interface().(Node)
+		return withKey(&n, key), nil
+	}
+	if v.Type().Implements(textMarshalerType) {
+		return encodeText(key, v)
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		n := NewObject(key)
+		for _, f := range cachedFields(v.Type()) {
+			fv, ok := fieldByIndexNoAlloc(v, f.index)
+			if !ok || (f.omitEmpty && fv.IsZero()) {
+				continue
+			}
+			c, err := encodeValue(f.name, fv)
+			if err != nil {
+				return nil, err
+			}
+			if c != nil {
+				n.Append(c)
+			}
+		}
+		return n, nil
+
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		keys := make([]string, 0, v.Len())
+		values := make(map[string]reflect.Value, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			k, _, err := formatScalar(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, k)
+			values[k] = iter.Value()
+		}
+		sort.Strings(keys)
+
+		n := NewObject(key)
+		for _, k := range keys {
+			c, err := encodeValue(k, values[k])
+			if err != nil {
+				return nil, err
+			}
+			if c != nil {
+				n.Append(c)
+			}
+		}
+		return n, nil
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		n := NewObject(key)
+		for i := 0; i < v.Len(); i++ {
+			c, err := encodeValue(strconv.Itoa(i), v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			if c != nil {
+				n.Append(c)
+			}
+		}
+		return n, nil
+	}
+
+	s, typ, err := formatScalar(v)
+	if err != nil {
+		return nil, err
+	}
+	n := NewValue(key, s)
+	n.Type = typ
+	return n, nil
+}
+
+func encodeText(key string, v reflect.Value) (*Node, error) {
+	text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return nil, fmt.Errorf("vdf: key %q: %w", key, err)
+	}
+	return NewValue(key, string(text)), nil
+}
+
+// formatScalar returns the string form of v and the Type it is stored as in binary KeyValues
+func formatScalar(v reflect.Value) (string, Type, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), String, nil
+	case reflect.Bool:
+		if v.Bool() {
+			return "1", Int32, nil
+		}
+		return "0", Int32, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return strconv.FormatInt(v.Int(), 10), Int32, nil
+	case reflect.Int, reflect.Int64:
+		i := v.Int()
+		if v.Kind() == reflect.Int && i >= math.MinInt32 && i <= math.MaxInt32 {
+			return strconv.FormatInt(i, 10), Int32, nil
+		}
+		return strconv.FormatInt(i, 10), Int64, nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return strconv.FormatUint(v.Uint(), 10), Int32, nil
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), Uint64, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), Float32, nil
+	}
+	return "", String, &UnsupportedTypeError{Type: v.Type()}
+}
+
+// withKey returns n, or a copy of n under a different key
+func withKey(n *Node, key string) *Node {
+	if key == "" || n.Key == key {
+		return n
+	}
+	c := *n
+	c.Key = key
+	return &c
+}
+
+// fieldByIndexNoAlloc is like reflect.Value.FieldByIndex, but reports false for fields behind nil embedded pointers
+func fieldByIndexNoAlloc(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
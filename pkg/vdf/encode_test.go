@@ -0,0 +1,116 @@
+package vdf
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lowerText implements encoding.TextMarshaler
+type lowerText string
+
+func (l lowerText) MarshalText() ([]byte, error) {
+	return []byte(strings.ToLower(string(l))), nil
+}
+
+func TestMarshal(t *testing.T) {
+	cityId := 3961
+	input := testPlayersWrapper{}
+	input.Response.Players = []testPlayer{
+		{
+			SteamID:     "76561197960435530",
+			PersonaName: "Robin \"Valve\"",
+			TimeCreated: 1063407589,
+			LocCityId:   &cityId,
+			Visible:     true,
+			Percent:     56.78,
+			Ignored:     "not written",
+		},
+	}
+
+	want := `"response"
+{
+	"players"
+	{
+		"0"
+		{
+			"steamid"		"76561197960435530"
+			"personaname"		"Robin \"Valve\""
+			"timecreated"		"1063407589"
+			"loccityid"		"3961"
+			"visible"		"1"
+			"percent"		"56.78"
+		}
+	}
+}`
+
+	got, err := Marshal(input)
+	assert.NoError(t, err)
+	assert.Equal(t, want, string(got))
+
+	// the output can be read back
+	var decoded testPlayersWrapper
+	assert.NoError(t, Unmarshal(got, &decoded))
+	input.Response.Players[0].Ignored = ""
+	assert.Equal(t, input, decoded)
+}
+
+func TestMarshalIndent(t *testing.T) {
+	type doc struct {
+		Name    string            `vdf:"name"`
+		Empty   string            `vdf:"empty,omitempty"`
+		Nil     *int              `vdf:"nil"`
+		Map     map[string]uint32 `vdf:"map"`
+		Text    lowerText         `vdf:"text"`
+		Escaped string            `vdf:"escaped"`
+	}
+
+	input := doc{
+		Name:    "app",
+		Map:     map[string]uint32{"b": 2, "a": 1},
+		Text:    "ABC",
+		Escaped: "line\nbreak\\",
+	}
+
+	want := `> "name"		"app"
+> "map"
+> {
+>   "a"		"1"
+>   "b"		"2"
+> }
+> "text"		"abc"
+> "escaped"		"line\nbreak\\"`
+
+	got, err := MarshalIndent(input, "> ", "  ")
+	assert.NoError(t, err)
+	assert.Equal(t, want, string(got))
+}
+
+func TestEncodeNode(t *testing.T) {
+	root := NewObject("",
+		NewObject("AppState",
+			NewValue("appid", "440"),
+			NewObject("empty"),
+		),
+	)
+
+	var buf bytes.Buffer
+	assert.NoError(t, NewEncoder(&buf).Encode(root))
+	assert.Equal(t, "\"AppState\"\n{\n\t\"appid\"\t\t\"440\"\n\t\"empty\"\n\t{\n\t}\n}\n", buf.String())
+
+	parsed, err := Parse(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, root, parsed)
+}
+
+func TestMarshalErrors(t *testing.T) {
+	_, err := Marshal(struct {
+		C chan int `vdf:"c"`
+	}{C: make(chan int)})
+
+	var typeErr *UnsupportedTypeError
+	assert.True(t, errors.As(err, &typeErr), "expected *UnsupportedTypeError, got %v", err)
+}
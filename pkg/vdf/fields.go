@@ -0,0 +1,81 @@
+package vdf
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// field describes a struct field that is mapped to a key
+type field struct {
+	name      string // key name
+	index     []int  // index sequence for reflect.Value.FieldByIndex
+	omitEmpty bool
+}
+
+var fieldCache sync.Map // map[reflect.Type][]field
+
+/*
+cachedFields returns the mapped fields of a struct type.
+
+The key name is taken from the `vdf` tag, falling back to the `json` tag and finally the field name,
+so the model structs of this module can be used without additional tags. Embedded structs are flattened.
+*/
+func cachedFields(t reflect.Type) []field {
+	if f, ok := fieldCache.Load(t); ok {
+		return f.([]field)
+	}
+	fields := typeFields(t, nil)
+	f, _ := fieldCache.LoadOrStore(t, fields)
+	return f.([]field)
+}
+
+func typeFields(t reflect.Type, index []int) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		idx := append(append([]int{}, index...), i)
+
+		tag, hasTag := sf.Tag.Lookup("vdf")
+		if !hasTag {
+			tag, hasTag = sf.Tag.Lookup("json")
+		}
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if sf.Anonymous && (!hasTag || name == "") {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, typeFields(ft, idx)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, field{
+			name:      name,
+			index:     idx,
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+		})
+	}
+	return fields
+}
+
+// lookupField returns the field matching key (case-insensitive), or nil
+func lookupField(fields []field, key string) *field {
+	for i := range fields {
+		if strings.EqualFold(fields[i].name, key) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
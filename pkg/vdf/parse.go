@@ -0,0 +1,256 @@
+package vdf
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SyntaxError describes malformed KeyValues text
+type SyntaxError struct {
+	Line int    // line of the document the error was found in (1-based)
+	Msg  string // description of the error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("vdf: syntax error in line %d: %s", e.Line, e.Msg)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokString
+	tokOpen
+	tokClose
+	tokCondition // platform conditionals like [$WIN32], which are ignored
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+type scanner struct {
+	r    *bufio.Reader
+	line int
+}
+
+func newScanner(r io.Reader) *scanner {
+	return &scanner{r: bufio.NewReader(r), line: 1}
+}
+
+func (s *scanner) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Line: s.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (s *scanner) readRune() (rune, error) {
+	r, _, err := s.r.ReadRune()
+	if r == '\n' {
+		s.line++
+	}
+	return r, err
+}
+
+func (s *scanner) unreadRune(r rune) {
+	_ = s.r.UnreadRune()
+	if r == '\n' {
+		s.line--
+	}
+}
+
+// skips whitespace and // comments
+func (s *scanner) skipSpace() error {
+	for {
+		r, err := s.readRune()
+		if err != nil {
+			return err
+		}
+		switch {
+		case r == '\uFEFF' || r == ' ' || r == '\t' || r == '\r' || r == '\n':
+			continue
+		case r == '/':
+			if next, err := s.r.Peek(1); err != nil || next[0] != '/' {
+				s.unreadRune(r)
+				return nil
+			}
+			if _, err := s.r.ReadString('\n'); err != nil {
+				return err
+			}
+			s.line++
+		default:
+			s.unreadRune(r)
+			return nil
+		}
+	}
+}
+
+func (s *scanner) next() (token, error) {
+	if err := s.skipSpace(); err != nil {
+		if errors.Is(err, io.EOF) {
+			return token{kind: tokEOF, line: s.line}, nil
+		}
+		return token{}, err
+	}
+
+	line := s.line
+	r, err := s.readRune()
+	if err != nil {
+		return token{}, err
+	}
+
+	switch r {
+	case '{':
+		return token{kind: tokOpen, text: "{", line: line}, nil
+	case '}':
+		return token{kind: tokClose, text: "}", line: line}, nil
+	case '"':
+		text, err := s.quoted()
+		return token{kind: tokString, text: text, line: line}, err
+	case '[':
+		text, err := s.until(']')
+		return token{kind: tokCondition, text: text, line: line}, err
+	default:
+		s.unreadRune(r)
+		return token{kind: tokString, text: s.unquoted(), line: line}, nil
+	}
+}
+
+// reads a quoted string, the opening quote has already been consumed
+func (s *scanner) quoted() (string, error) {
+	var sb strings.Builder
+	for {
+		r, err := s.readRune()
+		if err != nil {
+			return "", s.errorf("unterminated string")
+		}
+		switch r {
+		case '"':
+			return sb.String(), nil
+		case '\\':
+			esc, err := s.readRune()
+			if err != nil {
+				return "", s.errorf("unterminated string")
+			}
+			switch esc {
+			case 'n':
+				sb.WriteRune('\n')
+			case 't':
+				sb.WriteRune('\t')
+			case 'r':
+				sb.WriteRune('\r')
+			case '\\', '"':
+				sb.WriteRune(esc)
+			default:
+				// unknown escapes are kept as they are, like Valve's parser does
+				sb.WriteRune('\\')
+				sb.WriteRune(esc)
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+}
+
+// reads an unquoted string, which ends at whitespace, braces or quotes
+func (s *scanner) unquoted() string {
+	var sb strings.Builder
+	for {
+		r, err := s.readRune()
+		if err != nil {
+			return sb.String()
+		}
+		switch r {
+		case ' ', '\t', '\r', '\n', '{', '}', '"':
+			s.unreadRune(r)
+			return sb.String()
+		}
+		sb.WriteRune(r)
+	}
+}
+
+func (s *scanner) until(end rune) (string, error) {
+	var sb strings.Builder
+	for {
+		r, err := s.readRune()
+		if err != nil {
+			return "", s.errorf("missing %q", end)
+		}
+		if r == end {
+			return sb.String(), nil
+		}
+		sb.WriteRune(r)
+	}
+}
+
+// returns the next token that is not a conditional
+func (s *scanner) nextSignificant() (token, error) {
+	for {
+		tok, err := s.next()
+		if err != nil || tok.kind != tokCondition {
+			return tok, err
+		}
+	}
+}
+
+/*
+Parse reads a KeyValues text document from r.
+
+The returned root node has an empty key and holds the top-level keys of the document as its children.
+Comments and platform conditionals ([$WIN32] etc.) are skipped.
+*/
+func Parse(r io.Reader) (*Node, error) {
+	s := newScanner(r)
+	root := NewObject("")
+	if err := parseObject(s, root, true); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// parses key/value pairs into parent until the closing brace (or EOF for the top level)
+func parseObject(s *scanner, parent *Node, topLevel bool) error {
+	for {
+		tok, err := s.nextSignificant()
+		if err != nil {
+			return err
+		}
+
+		switch tok.kind {
+		case tokEOF:
+			if !topLevel {
+				return s.errorf("unexpected end of document, missing '}'")
+			}
+			return nil
+		case tokClose:
+			if topLevel {
+				return &SyntaxError{Line: tok.line, Msg: "unexpected '}'"}
+			}
+			return nil
+		case tokOpen:
+			return &SyntaxError{Line: tok.line, Msg: "expected key, found '{'"}
+		}
+
+		key := tok.text
+		val, err := s.nextSignificant()
+		if err != nil {
+			return err
+		}
+
+		switch val.kind {
+		case tokString:
+			parent.Append(NewValue(key, val.text))
+		case tokOpen:
+			child := NewObject(key)
+			if err := parseObject(s, child, false); err != nil {
+				return err
+			}
+			parent.Append(child)
+		default:
+			return &SyntaxError{Line: val.line, Msg: fmt.Sprintf("expected value or '{' after key %q", key)}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package vdf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	input := `// a comment
+"AppState"
+{
+	"appid"		"440"
+	"name"		"Team \"Fortress\" 2"
+	unquoted	value // trailing comment
+	"UserConfig"
+	{
+		"language"		"english"
+	}
+	"platform"	"windows"	[$WIN32]
+	"empty"
+	{
+	}
+}`
+
+	root, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err)
+
+	want := NewObject("",
+		NewObject("AppState",
+			NewValue("appid", "440"),
+			NewValue("name", `Team "Fortress" 2`),
+			NewValue("unquoted", "value"),
+			NewObject("UserConfig",
+				NewValue("language", "english"),
+			),
+			NewValue("platform", "windows"),
+			NewObject("empty"),
+		),
+	)
+	assert.Equal(t, want, root)
+
+	assert.Equal(t, "english", root.Find("appstate", "userconfig", "LANGUAGE").Value)
+	assert.Nil(t, root.Find("AppState", "missing"))
+	assert.True(t, root.Find("AppState", "empty").IsObject())
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  int
+	}{
+		{name: "missing closing brace", input: "\"a\"\n{\n\"b\" \"c\"\n", line: 4},
+		{name: "unexpected closing brace", input: "\"a\" \"b\"\n}", line: 2},
+		{name: "unterminated string", input: "\"a\" \"b", line: 1},
+		{name: "key without value", input: "\"a\" \"b\"\n\"c\"", line: 2},
+		{name: "brace instead of key", input: "{", line: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			var syntaxErr *SyntaxError
+			if assert.True(t, errors.As(err, &syntaxErr), "expected *SyntaxError, got %v", err) {
+				assert.Equal(t, tt.line, syntaxErr.Line)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Package vdf implements Valve's KeyValues format, better known as VDF.
+
+KeyValues documents are trees of keys. Every key either holds a string value or a list of child keys:
+
+	"response"
+	{
+		"players"
+		{
+			"0"
+			{
+				"steamid"	"76561197960435530"
+			}
+		}
+	}
+
+Documents can be decoded into a generic Node tree or, like encoding/json, into structs. Struct fields are matched
+by their `vdf` tag, falling back to the `json` tag and the field name. Keys are compared case-insensitively.
+
+Besides the text format, the binary KeyValues format used by shortcuts.vdf, appinfo.vdf and packageinfo.vdf
+is supported with the same mapping rules.
+*/
+package vdf
+
+import "strings"
+
+// Type is the type of a leaf value. Text KeyValues only know strings, binary KeyValues also store numbers.
+type Type int
+
+const (
+	String     Type = iota // UTF-8 string
+	Int32                  // signed 32-bit integer
+	Float32                // 32-bit float
+	Pointer                // 32-bit pointer, only found in binary data
+	WideString             // UTF-16 string
+	Color                  // 32-bit RGBA color
+	Uint64                 // unsigned 64-bit integer
+	Int64                  // signed 64-bit integer
+)
+
+// A Node is a single key of a KeyValues document.
+// Leaf nodes hold a Value, object nodes hold their Children in document order.
+type Node struct {
+	Key      string
+	Value    string  // value of a leaf node; numbers are stored in their decimal form
+	Type     Type    // type of the value of a leaf node
+	Children []*Node // nil for leaf nodes, non-nil (but possibly empty) for objects
+}
+
+// NewObject creates an object node with the given key and children
+func NewObject(key string, children ...*Node) *Node {
+	if children == nil {
+		children = []*Node{}
+	}
+	return &Node{Key: key, Children: children}
+}
+
+// NewValue creates a leaf node with the given key and value
+func NewValue(key, value string) *Node {
+	return &Node{Key: key, Value: value}
+}
+
+// IsObject reports whether the node holds child keys instead of a value
+func (n *Node) IsObject() bool {
+	return n.Children != nil
+}
+
+// Child returns the first direct child with the given key (case-insensitive), or nil
+func (n *Node) Child(key string) *Node {
+	for _, c := range n.Children {
+		if strings.EqualFold(c.Key, key) {
+			return c
+		}
+	}
+	return nil
+}
+
+// Find follows the given path of keys from n and returns the node at its end, or nil
+func (n *Node) Find(path ...string) *Node {
+	cur := n
+	for _, key := range path {
+		if cur = cur.Child(key); cur == nil {
+			return nil
+		}
+	}
+	return cur
+}
+
+// Append adds children to the node, turning it into an object node
+func (n *Node) Append(children ...*Node) {
+	if n.Children == nil {
+		n.Children = []*Node{}
+	}
+	n.Children = append(n.Children, children...)
+}